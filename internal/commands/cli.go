@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"claude-company/internal/config"
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/session"
+)
+
+// Exit codes shared by all subcommands
+const (
+	ExitOK          = 0
+	ExitFailure     = 1   // the command ran but failed
+	ExitUsage       = 2   // invalid flags or arguments
+	ExitNoSession   = 3   // the tmux session does not exist
+	ExitNotFound    = 4   // a task, plan or pane does not exist
+	ExitTmuxMissing = 5   // tmux is not installed
+	ExitInterrupted = 130 // stopped by SIGINT or SIGTERM
+)
+
+// Command is a CLI subcommand
+type Command interface {
+	Execute(ctx context.Context) error
+}
+
+// ExitError is an error that carries the process exit code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode maps the error returned by a command to a process exit code
+func ExitCode(err error) int {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
+
+func exitError(code int, format string, args ...any) error {
+	return &ExitError{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// parseFlags parses args and reports bad flags as usage errors
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &ExitError{Code: ExitUsage, Err: err}
+	}
+	return nil
+}
+
+// configFlag selects the configuration file and session. Without them
+// config.Resolve looks for .claude-company.yaml and the other default
+// locations, and names the session after the project directory.
+type configFlag struct {
+	path    string
+	session string
+}
+
+func (f *configFlag) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.path, "config", "", "Configuration file (default: $"+config.ConfigEnvVar+" or .claude-company.yaml when present)")
+	fs.StringVar(&f.session, "session", "", "tmux session name (default: session.name, $"+config.SessionEnvVar+" or claude-<project directory>)")
+}
+
+// load resolves the configuration from the file and environment variables
+func (f *configFlag) load() (*config.OrchestratorConfig, error) {
+	cfg, err := config.Resolve(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, &ExitError{Code: ExitNotFound, Err: err}
+	}
+	if err != nil {
+		return nil, &ExitError{Code: ExitUsage, Err: err}
+	}
+	if f.session != "" {
+		cfg.Session.Name = f.session
+	}
+	return cfg, nil
+}
+
+// sessionManager creates a manager for the configured session, e.g. to
+// inspect its state
+func (f *configFlag) sessionManager() (*session.Manager, error) {
+	cfg, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	return session.NewManagerFromConfig(cfg), nil
+}
+
+// managerFlags are the flags shared by commands that drive a session
+type managerFlags struct {
+	configFlag
+	orchestrate      bool
+	storage          string
+	readyTimeout     time.Duration
+	interruptWorkers bool
+}
+
+func (f *managerFlags) register(fs *flag.FlagSet) {
+	f.configFlag.register(fs)
+	fs.BoolVar(&f.orchestrate, "orchestrate", false, "Enable orchestrator mode for step-based task management")
+	fs.StringVar(&f.storage, "storage", session.StorageBackendFile, "Storage backend for orchestrator state (file or sqlite)")
+	fs.DurationVar(&f.readyTimeout, "ready-timeout", 60*time.Second, "How long to wait for Claude to start in a pane")
+	fs.BoolVar(&f.interruptWorkers, "interrupt-workers", false, "On SIGINT/SIGTERM, interrupt busy workers instead of letting them finish their step")
+}
+
+func (f *managerFlags) newManager() (*session.Manager, error) {
+	manager, err := f.sessionManager()
+	if err != nil {
+		return nil, err
+	}
+	if err := manager.SetStorageBackend(f.storage); err != nil {
+		return nil, &ExitError{Code: ExitUsage, Err: err}
+	}
+	manager.SetReadinessTimeout(f.readyTimeout)
+	manager.SetInterruptOnShutdown(f.interruptWorkers)
+	if f.orchestrate {
+		manager.SetOrchestratorMode(true)
+		fmt.Println("🔧 Orchestrator mode enabled")
+	}
+	return manager, nil
+}
+
+func requireTmux() error {
+	if err := session.NewTmuxMultiplexer().Check(); err != nil {
+		return exitError(ExitTmuxMissing, "tmux is not installed")
+	}
+	return nil
+}
+
+func requireSession(name string) error {
+	if err := requireTmux(); err != nil {
+		return err
+	}
+	if !session.NewTmuxSessionManager().SessionExists(name) {
+		return exitError(ExitNoSession, "session '%s' does not exist (run `claude-company setup` first)", name)
+	}
+	return nil
+}
+
+// closeStorage closes storage backends that hold open resources
+func closeStorage(storage orchestrator.Storage) {
+	if closer, ok := storage.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// SignalContext returns a context that is cancelled with
+// orchestrator.ErrShutdown on SIGINT or SIGTERM. A second signal terminates
+// the process right away.
+func SignalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+			fmt.Fprintf(os.Stderr, "\n⚠️  %s を受信しました。停止しています... (もう一度で強制終了)\n", sig)
+			cancel(orchestrator.ErrShutdown)
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel(context.Canceled)
+	}
+}
+
+// shutdownTimeout bounds how long running steps get to wind down
+const shutdownTimeout = 30 * time.Second
+
+// finishRun shuts the orchestrator down when ctx was cancelled by a signal
+// and prints what was left unfinished; otherwise err is returned as is
+func finishRun(ctx context.Context, manager *session.Manager, err error) error {
+	if !errors.Is(context.Cause(ctx), orchestrator.ErrShutdown) {
+		return err
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	summary, shutdownErr := manager.Shutdown(shutdownCtx)
+	if shutdownErr != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", shutdownErr)
+	}
+	printShutdownSummary(summary)
+
+	return exitError(ExitInterrupted, "interrupted")
+}
+
+func printShutdownSummary(summary *session.ShutdownSummary) {
+	if summary.Plan == nil {
+		fmt.Println("🛑 停止しました")
+		return
+	}
+
+	fmt.Printf("🛑 タスク %s を停止しました (未完了 %d/%dステップ)\n", summary.Task.ID, len(summary.Unfinished), len(summary.Plan.Steps))
+	for _, step := range summary.Unfinished {
+		fmt.Printf("   - %d. %s [%s]\n", step.Order, step.Name, step.Status)
+	}
+	for _, worker := range summary.Running {
+		fmt.Printf("   ⏳ %s (pane %s) はステップ %s の作業を続けています\n", worker.Name, worker.ID, *worker.CurrentTask)
+	}
+	for _, worker := range summary.Interrupted {
+		fmt.Printf("   ✋ %s (pane %s) のステップ %s を中断しました\n", worker.Name, worker.ID, *worker.CurrentTask)
+	}
+	if len(summary.Unfinished) > 0 {
+		fmt.Printf("💡 続きは claude-company resume %s で実行できます\n", summary.Plan.ID)
+	}
+}
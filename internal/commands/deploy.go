@@ -2,23 +2,44 @@ package commands
 
 import (
 	"context"
+	"claude-company/internal/orchestrator"
 	"claude-company/internal/session"
 	"fmt"
+	"strings"
 )
 
 type DeployCommand struct {
 	taskDesc string
+	request  orchestrator.TaskRequest
 	manager  *session.Manager
 }
 
 func NewDeployCommand(taskDesc string, manager *session.Manager) *DeployCommand {
 	return &DeployCommand{
 		taskDesc: taskDesc,
-		manager:  manager,
+		request: orchestrator.TaskRequest{
+			Type:        orchestrator.TaskTypeFeature,
+			Title:       taskTitle(taskDesc),
+			Description: taskDesc,
+			Priority:    orchestrator.TaskPriorityMedium,
+		},
+		manager: manager,
 	}
 }
 
+// NewDeployCommandForRequest deploys a task read from a spec, keeping its
+// type, priority and context
+func NewDeployCommandForRequest(req orchestrator.TaskRequest, manager *session.Manager) *DeployCommand {
+	c := NewDeployCommand(req.Description, manager)
+	c.request = req
+	return c
+}
+
 func (c *DeployCommand) Execute(ctx context.Context) error {
+	if err := c.manager.RegisterCompany(); err != nil {
+		return err
+	}
+
 	panes, err := c.manager.GetPanes()
 	if err != nil {
 		return fmt.Errorf("failed to get panes: %w", err)
@@ -58,6 +79,36 @@ func (c *DeployCommand) executeTraditionalMode(panes []string) error {
 }
 
 func (c *DeployCommand) executeOrchestratorMode(ctx context.Context, panes []string) error {
+	plan, err := c.preparePlan(ctx, panes)
+	if err != nil {
+		return err
+	}
+
+	if err := c.manager.ExecutePlan(ctx, plan.ID); err != nil {
+		return finishRun(ctx, c.manager, fmt.Errorf("plan execution failed: %w", err))
+	}
+
+	fmt.Printf("✅ タスク %s の全ステップが完了しました\n", plan.TaskID)
+	return nil
+}
+
+// PreparePlan registers the task and plans it in orchestrator mode without
+// executing the plan, e.g. so that the plan can be run under the UI
+func (c *DeployCommand) PreparePlan(ctx context.Context) (*orchestrator.TaskPlan, error) {
+	panes, err := c.manager.GetPanes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get panes: %w", err)
+	}
+
+	if len(panes) < 2 {
+		return nil, fmt.Errorf("need at least 2 panes for AI mode (manager + workers)")
+	}
+
+	c.manager.SetOrchestratorMode(true)
+	return c.preparePlan(ctx, panes)
+}
+
+func (c *DeployCommand) preparePlan(ctx context.Context, panes []string) (*orchestrator.TaskPlan, error) {
 	workerPane := panes[1]
 
 	// Set the main task in manager
@@ -65,22 +116,74 @@ func (c *DeployCommand) executeOrchestratorMode(ctx context.Context, panes []str
 
 	// Initialize orchestrator if not already done
 	if err := c.manager.InitializeOrchestrator(ctx); err != nil {
-		return fmt.Errorf("failed to initialize orchestrator: %w", err)
+		return nil, fmt.Errorf("failed to initialize orchestrator: %w", err)
+	}
+
+	// Register the task and build a step-based plan
+	resp, err := c.manager.CreateTask(ctx, c.request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
-	// Send orchestrator prompt to manager pane
-	orchestratorPrompt := c.manager.GetPromptForMode(workerPane)
-	if err := c.manager.SendToPane(workerPane, orchestratorPrompt); err != nil {
-		return fmt.Errorf("failed to send orchestrator prompt: %w", err)
+	plan, err := c.manager.CreatePlanForCurrentTask(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plan: %w", err)
+	}
+
+	fmt.Printf("🗂️  タスク %s のプラン %s を作成しました (%s, %dステップ)\n", resp.TaskID, plan.ID, plan.Strategy, len(plan.Steps))
+	for _, step := range plan.Steps {
+		fmt.Printf("   %d. %s\n", step.Order, step.Name)
 	}
 
-	fmt.Printf("🎯 オーケストレーターモード開始: 親ペイン %s がAIタスクオーケストレーターとして機能します\n", workerPane)
-	fmt.Printf("📋 役割分担: 親ペイン=オーケストレーション専用, 子ペイン=ステップベース実装専用\n")
+	if err := attachWorkers(c.manager, panes); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("🎯 オーケストレーターモード開始: 親ペイン %s が報告を受け取り、子ペインでステップを実行します\n", workerPane)
 	fmt.Printf("🔄 タスク: %s\n", c.taskDesc)
-	fmt.Printf("⚡ ワーカーペイン %s でステップベース実行を行ってください\n", workerPane)
-	fmt.Printf("🧠 機能: 自動ステップ分解、並列実行最適化、品質監視、自動リトライ\n")
 	fmt.Printf("📊 モード: %s\n", c.manager.GetModeStatus())
+	return plan, nil
+}
+
+// startOrchestrator prepares the session to run tasks in orchestrator mode
+// without registering a task, e.g. to run the queue or a manifest
+func startOrchestrator(ctx context.Context, manager *session.Manager) error {
+	manager.SetOrchestratorMode(true)
 
+	panes, err := manager.GetPanes()
+	if err != nil {
+		return fmt.Errorf("failed to get panes: %w", err)
+	}
+	if len(panes) < 2 {
+		return fmt.Errorf("need at least 2 panes for AI mode (manager + workers)")
+	}
+	if err := manager.InitializeOrchestrator(ctx); err != nil {
+		return fmt.Errorf("failed to initialize orchestrator: %w", err)
+	}
+	return attachWorkers(manager, panes)
+}
+
+// attachWorkers dispatches steps to child panes; the manager pane receives
+// reports. Parent panes recorded by an earlier run are kept: tmux lists panes
+// by position, so worker panes split off later may come before them.
+func attachWorkers(manager *session.Manager, panes []string) error {
+	if err := manager.LoadSessionState(); err != nil {
+		return err
+	}
+	var parents []string
+	for _, pane := range panes {
+		if manager.IsParentPane(pane) {
+			parents = append(parents, pane)
+		}
+	}
+	if len(parents) < 2 {
+		parents = panes[:2]
+	}
+
+	manager.MarkParentPanes(parents[0], parents[1])
+	if _, err := manager.AttachStepExecutor(parents[1]); err != nil {
+		return fmt.Errorf("failed to attach step executor: %w", err)
+	}
 	return nil
 }
 
@@ -88,3 +191,13 @@ func (c *DeployCommand) executeOrchestratorMode(ctx context.Context, panes []str
 func (c *DeployCommand) executeAIMode(panes []string) error {
 	return c.executeTraditionalMode(panes)
 }
+
+// taskTitle derives a short title from the first line of a task description
+func taskTitle(desc string) string {
+	title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(desc), "\n", 2)[0])
+	runes := []rune(title)
+	if len(runes) > 50 {
+		return string(runes[:50]) + "..."
+	}
+	return title
+}
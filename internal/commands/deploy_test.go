@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/report"
+	"claude-company/internal/session"
+)
+
+// reportStepPattern finds the step a worker prompt asks to report on
+var reportStepPattern = regexp.MustCompile(`report --inbox \S+ --step '?([^' ]+)`)
+
+// newFakeSession returns a manager for session "test" whose main and manager
+// panes run on a fake multiplexer. The fake starts Claude when "claude" is
+// entered, and Claude reports every step it is given as completed.
+func newFakeSession(t *testing.T) (*session.Manager, *session.FakeMultiplexer) {
+	t.Helper()
+	t.Setenv(session.RegistryDirEnvVar, t.TempDir())
+	chdir(t, t.TempDir())
+	stubClaude(t)
+
+	m := session.NewManager("test", "claude")
+	fake := session.NewFakeMultiplexer()
+	m.SetMultiplexer(fake)
+	m.SetEventLogging(false)
+	m.Delivery().Interval = 10 * time.Millisecond
+	m.ReadinessProbe().SetDetectors(&session.ProcessDetector{}, &session.PromptBoxDetector{})
+	m.ReadinessProbe().Interval = 10 * time.Millisecond
+
+	inbox, err := report.NewInbox(m.InboxDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.SetResponder(func(pane *session.FakePane, input string) {
+		if input == "claude" {
+			pane.Command = "claude"
+			pane.Write("╭────────╮\n│ > \n╰────────╯")
+			return
+		}
+		if match := reportStepPattern.FindStringSubmatch(input); match != nil {
+			pane.Write("● done")
+			inbox.Submit(&report.Report{StepID: match[1], Status: report.StatusCompleted, Summary: "done"})
+		}
+	})
+
+	if err := fake.NewSession("test", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fake.Split("%0"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { m.Shutdown(context.Background()) })
+	return m, fake
+}
+
+// stubClaude puts an executable named claude first on PATH, so that the
+// Claude binary check passes
+func stubClaude(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "claude"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// chdir changes the working directory, where state is kept, until the test ends
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestDeployOrchestrated(t *testing.T) {
+	m, fake := newFakeSession(t)
+	m.SetOrchestratorMode(true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Plan state is polled while the plan runs, as the UI does
+	polling := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-polling:
+				return
+			case <-time.After(5 * time.Millisecond):
+			}
+			if task := m.GetCurrentTask(); task != nil && task.Plan != nil {
+				m.PlanSteps(ctx, task.Plan.ID)
+			}
+		}
+	}()
+
+	err := NewDeployCommand("Add a health endpoint\nReturn 200 from /healthz", m).Execute(ctx)
+	close(polling)
+	<-done
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	task := m.GetCurrentTask()
+	if task == nil || task.Plan == nil {
+		t.Fatal("no task was planned")
+	}
+	steps, err := m.PlanSteps(ctx, task.Plan.ID)
+	if err != nil {
+		t.Fatalf("PlanSteps: %v", err)
+	}
+	if len(steps) == 0 {
+		t.Fatal("plan has no steps")
+	}
+
+	// Every step was completed by a worker pane that was given its prompt
+	workers := m.WorkerPool().Workers()
+	if len(workers) == 0 {
+		t.Fatal("no worker was started")
+	}
+	for _, step := range steps {
+		if step.Status != orchestrator.TaskStatusCompleted {
+			t.Errorf("step %s (%s) is %s, want completed", step.ID, step.Name, step.Status)
+		}
+		if !dispatched(fake, workers, step.ID) {
+			t.Errorf("step %s was not sent to a worker", step.ID)
+		}
+	}
+	for _, parent := range []string{"%0", "%1"} {
+		if m.IsChildPane(parent) {
+			t.Errorf("pane %s is not a parent pane", parent)
+		}
+		for _, input := range fake.Sent(parent) {
+			if reportStepPattern.MatchString(input) {
+				t.Errorf("a step was sent to parent pane %s", parent)
+			}
+		}
+	}
+}
+
+// dispatched reports whether one of the workers was asked to report on stepID
+func dispatched(fake *session.FakeMultiplexer, workers []*orchestrator.Worker, stepID string) bool {
+	for _, worker := range workers {
+		for _, input := range fake.Sent(worker.ID) {
+			if match := reportStepPattern.FindStringSubmatch(input); match != nil && match[1] == stepID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestDeployNeedsTwoPanes(t *testing.T) {
+	m, fake := newFakeSession(t)
+	if err := fake.KillPane("%1"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := NewDeployCommand("task", m).Execute(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "need at least 2 panes") {
+		t.Errorf("Execute = %v, want the 2 pane error", err)
+	}
+}
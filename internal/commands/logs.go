@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/session"
+)
+
+// LogsCommand prints the persisted orchestrator event log, or the output of
+// a worker pane with --pane
+type LogsCommand struct {
+	args []string
+}
+
+func NewLogsCommand(args []string) *LogsCommand {
+	return &LogsCommand{
+		args: args,
+	}
+}
+
+func (c *LogsCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("logs", flag.ContinueOnError)
+	storageBackend := fs.String("storage", session.StorageBackendFile, "Storage backend for orchestrator state (file or sqlite)")
+	var cf configFlag
+	cf.register(fs)
+	taskID := fs.String("task", "", "Only show events of this task")
+	types := fs.String("type", "", "Comma-separated event types to show (e.g. task_failed,task_retried)")
+	limit := fs.Int("limit", 50, "Number of most recent events to show (0 for all)")
+	follow := fs.Bool("follow", false, "Keep printing new events")
+	pane := fs.String("pane", "", "Print the output of this pane instead of events")
+	lines := fs.Int("lines", 100, "Number of pane lines to print with --pane")
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return exitError(ExitUsage, "logs takes no arguments")
+	}
+
+	manager, err := cf.sessionManager()
+	if err != nil {
+		return err
+	}
+	if *pane != "" {
+		if err := requireSession(manager.SessionName); err != nil {
+			return err
+		}
+		output, err := manager.CapturePane(*pane, *lines)
+		if err != nil {
+			return &ExitError{Code: ExitNotFound, Err: err}
+		}
+		fmt.Print(output)
+		return nil
+	}
+
+	if err := manager.SetStorageBackend(*storageBackend); err != nil {
+		return &ExitError{Code: ExitUsage, Err: err}
+	}
+	storage, err := manager.OpenStorage()
+	if err != nil {
+		return err
+	}
+	defer closeStorage(storage)
+
+	filter := orchestrator.EventFilter{}
+	if *taskID != "" {
+		filter.TaskIDs = []string{*taskID}
+	}
+	for _, t := range strings.Split(*types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			filter.EventTypes = append(filter.EventTypes, orchestrator.TaskEventType(t))
+		}
+	}
+
+	events, err := storage.ListEvents(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if *limit > 0 && len(events) > *limit {
+		events = events[len(events)-*limit:]
+	}
+	for _, event := range events {
+		printEvent(event)
+	}
+	if !*follow {
+		return nil
+	}
+
+	seen := len(events)
+	if all, err := storage.ListEvents(ctx, filter); err == nil {
+		seen = len(all)
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		all, err := storage.ListEvents(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, event := range all[min(seen, len(all)):] {
+			printEvent(event)
+		}
+		seen = len(all)
+	}
+}
+
+func printEvent(event *orchestrator.TaskEvent) {
+	data := ""
+	if len(event.Data) > 0 {
+		if encoded, err := json.Marshal(event.Data); err == nil {
+			data = string(encoded)
+		}
+	}
+	fmt.Printf("%s  %-15s %-24s %s\n", event.Timestamp.Format("2006-01-02 15:04:05"), event.Type, event.TaskID, data)
+}
@@ -0,0 +1,253 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/session"
+)
+
+// queueCommands are the `task` subcommands that work on the task queue
+var queueCommands = map[string]func(args []string) Command{
+	"add":    func(args []string) Command { return NewTaskAddCommand(args) },
+	"list":   func(args []string) Command { return NewTaskListCommand(args) },
+	"cancel": func(args []string) Command { return NewTaskCancelCommand(args) },
+	"run":    func(args []string) Command { return NewTaskRunCommand(args) },
+}
+
+// openQueue opens the task queue kept in the given storage backend
+func openQueue(backend string) (*orchestrator.TaskQueue, orchestrator.Storage, error) {
+	// The queue lives in the project storage, shared by every session
+	manager := session.NewManager("", session.DefaultClaudeCmd)
+	if err := manager.SetStorageBackend(backend); err != nil {
+		return nil, nil, &ExitError{Code: ExitUsage, Err: err}
+	}
+	storage, err := manager.OpenStorage()
+	if err != nil {
+		return nil, nil, err
+	}
+	return orchestrator.NewTaskQueue(storage), storage, nil
+}
+
+// TaskAddCommand submits a task to the queue
+type TaskAddCommand struct {
+	args []string
+}
+
+func NewTaskAddCommand(args []string) *TaskAddCommand {
+	return &TaskAddCommand{
+		args: args,
+	}
+}
+
+func (c *TaskAddCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("task add", flag.ContinueOnError)
+	storageBackend := fs.String("storage", session.StorageBackendFile, "Storage backend for orchestrator state (file or sqlite)")
+	priority := fs.String("priority", "", "Task priority: high, medium or low (default medium, or the spec's front-matter)")
+	taskType := fs.String("type", "", "Task type: feature, bugfix, refactoring, documentation or research (default feature)")
+	var input taskInput
+	input.register(fs)
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+
+	req, err := input.request(fs, "claude-company task add [flags] <description>")
+	if err != nil {
+		return err
+	}
+	// Flags take precedence over the front-matter
+	if *priority != "" {
+		req.Priority = orchestrator.TaskPriority(*priority)
+		if !req.Priority.IsValid() {
+			return exitError(ExitUsage, "unknown priority: %s (expected high, medium or low)", *priority)
+		}
+	}
+	if *taskType != "" {
+		req.Type = orchestrator.TaskType(*taskType)
+		if !req.Type.IsValid() {
+			return exitError(ExitUsage, "unknown task type: %s", *taskType)
+		}
+	}
+
+	queue, storage, err := openQueue(*storageBackend)
+	if err != nil {
+		return err
+	}
+	defer closeStorage(storage)
+
+	task, err := queue.Add(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	queued, err := queue.List(ctx)
+	if err != nil {
+		return err
+	}
+	position := len(queued)
+	for i, t := range queued {
+		if t.ID == task.ID {
+			position = i + 1
+		}
+	}
+	fmt.Printf("📥 タスク %s をキューに追加しました (%s, %d/%d番目)\n", task.ID, task.Priority, position, len(queued))
+	return nil
+}
+
+// TaskListCommand lists the running and queued tasks
+type TaskListCommand struct {
+	args []string
+}
+
+func NewTaskListCommand(args []string) *TaskListCommand {
+	return &TaskListCommand{
+		args: args,
+	}
+}
+
+func (c *TaskListCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("task list", flag.ContinueOnError)
+	storageBackend := fs.String("storage", session.StorageBackendFile, "Storage backend for orchestrator state (file or sqlite)")
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return exitError(ExitUsage, "task list takes no arguments")
+	}
+
+	queue, storage, err := openQueue(*storageBackend)
+	if err != nil {
+		return err
+	}
+	defer closeStorage(storage)
+
+	running, err := storage.ListTasks(ctx, orchestrator.TaskFilter{
+		Status: []orchestrator.TaskStatus{orchestrator.TaskStatusInProgress},
+	})
+	if err != nil {
+		return err
+	}
+	queued, err := queue.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(running) == 0 && len(queued) == 0 {
+		fmt.Println("No queued tasks")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tTASK\tSTATUS\tPRIORITY\tTYPE\tAGE\tTITLE")
+	for _, task := range running {
+		printQueuedTask(w, "-", task)
+	}
+	for i, task := range queued {
+		printQueuedTask(w, fmt.Sprint(i+1), task)
+	}
+	return w.Flush()
+}
+
+func printQueuedTask(w *tabwriter.Writer, position string, task *orchestrator.Task) {
+	age := time.Since(task.CreatedAt).Round(time.Second)
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", position, task.ID, task.Status, task.Priority, task.Type, age, task.Title)
+}
+
+// TaskCancelCommand removes a task from the queue
+type TaskCancelCommand struct {
+	args []string
+}
+
+func NewTaskCancelCommand(args []string) *TaskCancelCommand {
+	return &TaskCancelCommand{
+		args: args,
+	}
+}
+
+func (c *TaskCancelCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("task cancel", flag.ContinueOnError)
+	storageBackend := fs.String("storage", session.StorageBackendFile, "Storage backend for orchestrator state (file or sqlite)")
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return exitError(ExitUsage, "usage: claude-company task cancel <task-id>")
+	}
+	taskID := fs.Arg(0)
+
+	queue, storage, err := openQueue(*storageBackend)
+	if err != nil {
+		return err
+	}
+	defer closeStorage(storage)
+
+	if _, err := storage.LoadTask(ctx, taskID); err != nil {
+		return &ExitError{Code: ExitNotFound, Err: err}
+	}
+	task, err := queue.Cancel(ctx, taskID)
+	if errors.Is(err, orchestrator.ErrTaskNotQueued) && task.Status == orchestrator.TaskStatusInProgress {
+		return fmt.Errorf("%w (stop it from the session running the queue)", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🗑️  タスク %s をキューから取り消しました: %s\n", task.ID, task.Title)
+	return nil
+}
+
+// TaskRunCommand executes the queued tasks one after another in orchestrator
+// mode, starting the next task as soon as the current plan completes
+type TaskRunCommand struct {
+	args []string
+}
+
+func NewTaskRunCommand(args []string) *TaskRunCommand {
+	return &TaskRunCommand{
+		args: args,
+	}
+}
+
+func (c *TaskRunCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("task run", flag.ContinueOnError)
+	var mf managerFlags
+	mf.register(fs)
+	watch := fs.Bool("watch", false, "Keep waiting for new tasks when the queue is empty")
+	poll := fs.Duration("poll", 5*time.Second, "How often an empty queue is checked with --watch")
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return exitError(ExitUsage, "task run takes no arguments")
+	}
+	manager, err := mf.newManager()
+	if err != nil {
+		return err
+	}
+	if err := requireSession(manager.SessionName); err != nil {
+		return err
+	}
+	if err := startOrchestrator(ctx, manager); err != nil {
+		return err
+	}
+
+	results, err := manager.DrainQueue(ctx, session.DrainOptions{
+		Watch:        *watch,
+		PollInterval: *poll,
+	})
+	if err != nil {
+		return finishRun(ctx, manager, err)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No queued tasks")
+		return finishRun(ctx, manager, nil)
+	}
+	return finishRun(ctx, manager, summarizeRuns("キュー", results))
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"claude-company/internal/report"
+	"claude-company/internal/session"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReportCommand submits a worker's completion report to the session inbox
+type ReportCommand struct {
+	args []string
+}
+
+func NewReportCommand(args []string) *ReportCommand {
+	return &ReportCommand{
+		args: args,
+	}
+}
+
+func (c *ReportCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	inboxDir := fs.String("inbox", "", "Report inbox directory (defaults to the session inbox under the current directory)")
+	var cf configFlag
+	cf.register(fs)
+	stepID := fs.String("step", "", "Step ID being reported")
+	status := fs.String("status", report.StatusCompleted, "Step result: completed or failed")
+	summary := fs.String("summary", "", "Short summary of the result (or the failure reason)")
+	details := fs.String("details", "", "Additional details")
+	artifacts := fs.String("artifacts", "", "Comma-separated list of produced files")
+	pane := fs.String("pane", os.Getenv("TMUX_PANE"), "Reporting pane ID")
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+
+	dir := *inboxDir
+	if dir == "" {
+		cfg, err := cf.load()
+		if err != nil {
+			return err
+		}
+		dir = session.NewManager(cfg.Session.Name, "").InboxDir()
+	}
+
+	inbox, err := report.NewInbox(dir)
+	if err != nil {
+		return err
+	}
+
+	rep := &report.Report{
+		StepID:  *stepID,
+		Status:  *status,
+		Summary: *summary,
+		Details: *details,
+		Pane:    *pane,
+	}
+	for _, artifact := range strings.Split(*artifacts, ",") {
+		if artifact = strings.TrimSpace(artifact); artifact != "" {
+			rep.Artifacts = append(rep.Artifacts, artifact)
+		}
+	}
+
+	if err := rep.Validate(); err != nil {
+		return &ExitError{Code: ExitUsage, Err: fmt.Errorf("invalid report: %w", err)}
+	}
+	if err := inbox.Submit(rep); err != nil {
+		return err
+	}
+
+	fmt.Printf("📨 Report for step %s submitted (%s)\n", rep.StepID, rep.Status)
+	return nil
+}
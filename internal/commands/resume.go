@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/session"
+)
+
+// ResumeCommand continues a plan that was interrupted by a crash or Ctrl-C.
+// Without a plan ID it picks the most recently interrupted plan, or returns
+// to the company session, recreating it if it was killed, and lists the
+// tasks that were left unfinished.
+type ResumeCommand struct {
+	args []string
+}
+
+func NewResumeCommand(args []string) *ResumeCommand {
+	return &ResumeCommand{
+		args: args,
+	}
+}
+
+func (c *ResumeCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("resume", flag.ContinueOnError)
+	var mf managerFlags
+	mf.register(fs)
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return exitError(ExitUsage, "usage: claude-company resume [flags] [plan-id]")
+	}
+	if err := requireTmux(); err != nil {
+		return err
+	}
+
+	manager, err := mf.newManager()
+	if err != nil {
+		return err
+	}
+
+	storage, err := manager.OpenStorage()
+	if err != nil {
+		return err
+	}
+	tasks, err := storage.ListTasks(ctx, orchestrator.TaskFilter{
+		Status: []orchestrator.TaskStatus{orchestrator.TaskStatusPending, orchestrator.TaskStatusInProgress},
+	})
+	planID := fs.Arg(0)
+	if err == nil && planID != "" {
+		if _, loadErr := storage.LoadPlan(ctx, planID); loadErr != nil {
+			err = &ExitError{Code: ExitNotFound, Err: loadErr}
+		}
+	}
+	closeStorage(storage)
+	if err != nil {
+		return err
+	}
+
+	sessionExists := session.NewTmuxSessionManager().SessionExists(manager.SessionName)
+	interrupted := interruptedPlans(tasks)
+	if planID == "" && sessionExists && len(interrupted) > 0 {
+		planID = interrupted[0].Plan.ID
+	}
+
+	if planID == "" {
+		return c.resumeSession(manager, tasks, interrupted, sessionExists)
+	}
+
+	if !sessionExists {
+		return exitError(ExitNoSession, "session '%s' does not exist (run `claude-company resume` to recreate it first)", manager.SessionName)
+	}
+	if err := startOrchestrator(ctx, manager); err != nil {
+		return err
+	}
+	if err := manager.ResumePlan(ctx, planID); err != nil {
+		return finishRun(ctx, manager, fmt.Errorf("plan %s: %w", planID, err))
+	}
+
+	fmt.Printf("✅ プラン %s の全ステップが完了しました\n", planID)
+	return nil
+}
+
+// resumeSession returns to the session and lists the unfinished tasks
+func (c *ResumeCommand) resumeSession(manager *session.Manager, tasks, interrupted []*orchestrator.Task, sessionExists bool) error {
+	if len(tasks) > 0 {
+		fmt.Printf("📋 %d unfinished task(s):\n", len(tasks))
+		for _, task := range tasks {
+			fmt.Printf("   %s [%s] %s\n", task.ID, task.Status, task.Title)
+		}
+	}
+	for _, task := range interrupted {
+		fmt.Printf("💡 Run `claude-company resume %s` in another terminal to continue task %s\n", task.Plan.ID, task.ID)
+	}
+
+	// Setup attaches when the session still exists
+	if sessionExists {
+		fmt.Printf("🔄 Resuming session '%s'\n", manager.SessionName)
+	}
+	return manager.Setup()
+}
+
+// interruptedPlans returns the tasks left in progress with a plan, most
+// recently updated first
+func interruptedPlans(tasks []*orchestrator.Task) []*orchestrator.Task {
+	var interrupted []*orchestrator.Task
+	for _, task := range tasks {
+		if task.Status == orchestrator.TaskStatusInProgress && task.Plan != nil {
+			interrupted = append(interrupted, task)
+		}
+	}
+	sort.SliceStable(interrupted, func(i, j int) bool {
+		return interrupted[i].UpdatedAt.After(interrupted[j].UpdatedAt)
+	})
+	return interrupted
+}
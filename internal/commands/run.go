@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/session"
+)
+
+// RunCommand creates the tasks of a YAML manifest and executes them in
+// dependency order in orchestrator mode
+type RunCommand struct {
+	args []string
+}
+
+func NewRunCommand(args []string) *RunCommand {
+	return &RunCommand{
+		args: args,
+	}
+}
+
+func (c *RunCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	var mf managerFlags
+	mf.register(fs)
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return exitError(ExitUsage, "usage: claude-company run [flags] <manifest.yaml>")
+	}
+
+	manifest, err := orchestrator.LoadTaskManifest(fs.Arg(0))
+	if errors.Is(err, os.ErrNotExist) {
+		return &ExitError{Code: ExitNotFound, Err: err}
+	}
+	if err != nil {
+		return &ExitError{Code: ExitUsage, Err: err}
+	}
+
+	fmt.Printf("📜 マニフェスト %s: %dタスク\n", fs.Arg(0), len(manifest.Tasks))
+	for i, item := range manifest.Order() {
+		fmt.Printf("   %d. %s", i+1, item.ID)
+		if len(item.DependsOn) > 0 {
+			fmt.Printf(" (depends on %v)", item.DependsOn)
+		}
+		fmt.Println()
+	}
+
+	manager, err := mf.newManager()
+	if err != nil {
+		return err
+	}
+	if err := requireSession(manager.SessionName); err != nil {
+		return err
+	}
+	if err := startOrchestrator(ctx, manager); err != nil {
+		return err
+	}
+
+	results, err := manager.RunManifest(ctx, manifest)
+	if err == nil {
+		err = summarizeRuns("マニフェスト", results)
+	}
+	return finishRun(ctx, manager, err)
+}
+
+// summarizeRuns prints the outcome of a batch of tasks and fails when any
+// of them did not complete
+func summarizeRuns(batch string, results []session.RunResult) error {
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	fmt.Printf("📊 %sの %d タスクを実行しました (失敗 %d)\n", batch, len(results), failed)
+	for _, result := range results {
+		if result.Task == nil {
+			continue
+		}
+		mark := "✅"
+		if result.Err != nil {
+			mark = "❌"
+		}
+		fmt.Printf("   %s %s [%s] %s\n", mark, result.Task.ID, result.Task.Status, result.Task.Title)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tasks did not complete", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"claude-company/internal/session"
+)
+
+// ListCommand lists the companies running on this machine, or with --all
+// every tmux session
+type ListCommand struct {
+	args []string
+}
+
+func NewListCommand(args []string) *ListCommand {
+	return &ListCommand{
+		args: args,
+	}
+}
+
+func (c *ListCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	all := fs.Bool("all", false, "List every tmux session, not only claude-company sessions")
+	jsonOutput := fs.Bool("json", false, "Print the companies as JSON")
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	if err := requireTmux(); err != nil {
+		return err
+	}
+	if !*all {
+		return listCompanies(*jsonOutput)
+	}
+
+	sessions, err := session.NewTmuxSessionManager().ListSessions()
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("No sessions running")
+		return nil
+	}
+	for _, s := range sessions {
+		fmt.Println(s)
+	}
+	return nil
+}
+
+// CompanyStatus is a registered company whose session is running
+type CompanyStatus struct {
+	session.Company
+	Panes int `json:"panes"`
+}
+
+// listCompanies prints the registered companies whose tmux session still
+// exists; entries of sessions that are gone are removed
+func listCompanies(jsonOutput bool) error {
+	registry, err := session.DefaultRegistry()
+	if err != nil {
+		return err
+	}
+	companies, err := registry.List()
+	if err != nil {
+		return fmt.Errorf("failed to read the registry: %w", err)
+	}
+
+	mux := session.NewTmuxMultiplexer()
+	active := []CompanyStatus{}
+	for _, company := range companies {
+		if !mux.HasSession(company.Session) {
+			registry.Unregister(company.Session)
+			continue
+		}
+		status := CompanyStatus{Company: company}
+		if panes, err := mux.ListPanes(company.Session); err == nil {
+			status.Panes = len(panes)
+		}
+		active = append(active, status)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(active)
+	}
+
+	if len(active) == 0 {
+		fmt.Println("No companies running (see --all for every tmux session)")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SESSION\tPROJECT\tLAYOUT\tPANES\tSTARTED")
+	for _, company := range active {
+		started := time.Since(company.StartedAt).Round(time.Second).String() + " ago"
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", company.Session, company.ProjectDir, company.Layout, company.Panes, started)
+	}
+	return w.Flush()
+}
+
+// AttachCommand attaches to (or switches the client to) a session
+type AttachCommand struct {
+	args []string
+}
+
+func NewAttachCommand(args []string) *AttachCommand {
+	return &AttachCommand{
+		args: args,
+	}
+}
+
+func (c *AttachCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("attach", flag.ContinueOnError)
+	var cf configFlag
+	cf.register(fs)
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	name, err := sessionArg(fs, &cf)
+	if err != nil {
+		return err
+	}
+	if err := requireSession(name); err != nil {
+		return err
+	}
+
+	return session.NewTmuxSessionManager().AttachSession(name)
+}
+
+// KillCommand kills a session and all of its worker panes
+type KillCommand struct {
+	args []string
+}
+
+func NewKillCommand(args []string) *KillCommand {
+	return &KillCommand{
+		args: args,
+	}
+}
+
+func (c *KillCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("kill", flag.ContinueOnError)
+	var cf configFlag
+	cf.register(fs)
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	name, err := sessionArg(fs, &cf)
+	if err != nil {
+		return err
+	}
+	if err := requireSession(name); err != nil {
+		return err
+	}
+
+	if err := session.NewTmuxSessionManager().KillSession(name); err != nil {
+		return fmt.Errorf("failed to kill session '%s': %w", name, err)
+	}
+	if registry, err := session.DefaultRegistry(); err == nil {
+		registry.Unregister(name)
+	}
+	fmt.Printf("🗑️  Session '%s' killed\n", name)
+	return nil
+}
+
+// RenameCommand renames a session
+type RenameCommand struct {
+	args []string
+}
+
+func NewRenameCommand(args []string) *RenameCommand {
+	return &RenameCommand{
+		args: args,
+	}
+}
+
+func (c *RenameCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("rename", flag.ContinueOnError)
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return exitError(ExitUsage, "usage: claude-company rename <old-name> <new-name>")
+	}
+	oldName, newName := fs.Arg(0), fs.Arg(1)
+	if err := requireSession(oldName); err != nil {
+		return err
+	}
+
+	if err := session.NewTmuxSessionManager().RenameSession(oldName, newName); err != nil {
+		return err
+	}
+	if err := renameCompany(oldName, newName); err != nil {
+		fmt.Printf("⚠️  Failed to update the registry: %v\n", err)
+	}
+	fmt.Printf("✏️  Session '%s' renamed to '%s'\n", oldName, newName)
+	return nil
+}
+
+// renameCompany moves the registry entry of a renamed session
+func renameCompany(oldName, newName string) error {
+	registry, err := session.DefaultRegistry()
+	if err != nil {
+		return err
+	}
+	company, err := registry.Get(oldName)
+	if err != nil || company == nil {
+		return err
+	}
+	company.Session = newName
+	company.UpdatedAt = time.Now()
+	if err := registry.Register(*company); err != nil {
+		return err
+	}
+	return registry.Unregister(oldName)
+}
+
+// sessionArg returns the optional session name argument, defaulting to the
+// configured session
+func sessionArg(fs *flag.FlagSet, cf *configFlag) (string, error) {
+	switch fs.NArg() {
+	case 0:
+		cfg, err := cf.load()
+		if err != nil {
+			return "", err
+		}
+		return cfg.Session.Name, nil
+	case 1:
+		return fs.Arg(0), nil
+	default:
+		return "", exitError(ExitUsage, "%s takes at most one session name", fs.Name())
+	}
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"context"
+	"flag"
+)
+
+// SetupCommand creates (or attaches to) the Claude Company tmux session
+type SetupCommand struct {
+	args []string
+}
+
+func NewSetupCommand(args []string) *SetupCommand {
+	return &SetupCommand{
+		args: args,
+	}
+}
+
+func (c *SetupCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("setup", flag.ContinueOnError)
+	var mf managerFlags
+	mf.register(fs)
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return exitError(ExitUsage, "setup takes no arguments")
+	}
+
+	if err := requireTmux(); err != nil {
+		return err
+	}
+
+	manager, err := mf.newManager()
+	if err != nil {
+		return err
+	}
+	return manager.Setup()
+}
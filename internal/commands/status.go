@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/session"
+)
+
+// StatusCommand shows what the team in the session is doing: each pane's
+// role and worker state, the step it works on and the progress of the plan
+type StatusCommand struct {
+	args []string
+}
+
+func NewStatusCommand(args []string) *StatusCommand {
+	return &StatusCommand{
+		args: args,
+	}
+}
+
+// StatusReport is the data shown by the status command (and printed by --json)
+type StatusReport struct {
+	Session string                     `json:"session"`
+	Panes   []PaneStatus               `json:"panes"`
+	Task    *orchestrator.Task         `json:"task,omitempty"`
+	Plan    *orchestrator.PlanProgress `json:"plan,omitempty"`
+	Steps   []orchestrator.TaskStep    `json:"steps,omitempty"`
+	// Assignments maps step IDs to the pane working on them
+	Assignments map[string]string    `json:"assignments"`
+	Tasks       []*orchestrator.Task `json:"recent_tasks"`
+}
+
+// PaneStatus is one pane of the session
+type PaneStatus struct {
+	session.PaneInfo
+	Role   string               `json:"role"` // parent or child
+	Worker *orchestrator.Worker `json:"worker,omitempty"`
+	Step   string               `json:"step,omitempty"`
+}
+
+func (c *StatusCommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	storageBackend := fs.String("storage", session.StorageBackendFile, "Storage backend for orchestrator state (file or sqlite)")
+	asJSON := fs.Bool("json", false, "Print the status as JSON")
+	var cf configFlag
+	cf.register(fs)
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+	manager, err := cf.sessionManager()
+	if err != nil {
+		return err
+	}
+	if err := requireSession(manager.SessionName); err != nil {
+		return err
+	}
+
+	if err := manager.SetStorageBackend(*storageBackend); err != nil {
+		return &ExitError{Code: ExitUsage, Err: err}
+	}
+
+	report, err := c.collect(ctx, manager)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return printStatus(report)
+}
+
+func (c *StatusCommand) collect(ctx context.Context, manager *session.Manager) (*StatusReport, error) {
+	if err := manager.LoadSessionState(); err != nil {
+		return nil, err
+	}
+	panes, err := manager.ListPaneDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := manager.OpenStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer closeStorage(storage)
+
+	workers, err := storage.ListWorkers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workersByPane := make(map[string]*orchestrator.Worker, len(workers))
+	for _, worker := range workers {
+		workersByPane[worker.ID] = worker
+	}
+
+	report := &StatusReport{
+		Session:     manager.SessionName,
+		Panes:       make([]PaneStatus, 0, len(panes)),
+		Assignments: make(map[string]string),
+	}
+	for _, pane := range panes {
+		status := PaneStatus{PaneInfo: pane, Role: "child"}
+		if manager.IsParentPane(pane.ID) {
+			status.Role = "parent"
+		}
+		if worker, exists := workersByPane[pane.ID]; exists {
+			status.Worker = worker
+			if worker.CurrentTask != nil {
+				status.Step = *worker.CurrentTask
+				report.Assignments[status.Step] = pane.ID
+			}
+		}
+		report.Panes = append(report.Panes, status)
+	}
+
+	report.Tasks, err = storage.ListTasks(ctx, orchestrator.TaskFilter{Limit: 5})
+	if err != nil {
+		return nil, err
+	}
+
+	// Show the plan of the running task, or else of the latest planned task
+	for _, task := range report.Tasks {
+		if task.Plan == nil {
+			continue
+		}
+		if report.Task == nil || task.Status == orchestrator.TaskStatusInProgress && report.Task.Status != orchestrator.TaskStatusInProgress {
+			report.Task = task
+		}
+	}
+	if report.Task != nil {
+		plans := orchestrator.NewTaskPlanManager(nil, storage, nil)
+		progress, err := plans.GetPlanProgress(ctx, report.Task.Plan.ID)
+		if err == nil {
+			report.Plan = progress
+			if plan, err := plans.GetPlan(ctx, report.Task.Plan.ID); err == nil {
+				report.Steps = plan.Steps
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func printStatus(report *StatusReport) error {
+	fmt.Printf("📊 Session '%s'\n\n", report.Session)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PANE\tROLE\tCOMMAND\tWORKER\tSTATE\tSTEP\tLAST SEEN")
+	for _, pane := range report.Panes {
+		command := pane.Command
+		if pane.Dead {
+			command += " (dead)"
+		}
+		worker, state, lastSeen := "-", "-", "-"
+		if pane.Worker != nil {
+			worker = pane.Worker.Name
+			if pane.Worker.Type != "" {
+				worker += " (" + pane.Worker.Type + ")"
+			}
+			state = string(pane.Worker.Status)
+			lastSeen = time.Since(pane.Worker.LastSeen).Round(time.Second).String() + " ago"
+		}
+		step := pane.Step
+		if step == "" {
+			step = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", pane.ID, pane.Role, command, worker, state, step, lastSeen)
+	}
+	w.Flush()
+
+	if report.Task != nil {
+		fmt.Printf("\n📋 Task %s [%s] %s\n", report.Task.ID, report.Task.Status, report.Task.Title)
+	}
+	if report.Plan != nil {
+		fmt.Printf("   Plan %s: %d/%d steps completed (%.0f%%), %d in progress, %d failed\n",
+			report.Plan.PlanID, report.Plan.CompletedSteps, report.Plan.TotalSteps, report.Plan.PercentComplete,
+			report.Plan.InProgressSteps, report.Plan.FailedSteps)
+		if report.Plan.EstimatedTimeRemaining != nil {
+			fmt.Printf("   Estimated time remaining: %s\n", report.Plan.EstimatedTimeRemaining.Round(time.Second))
+		}
+
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "#\tSTEP\tSTATUS\tPANE\tNAME")
+		for _, step := range report.Steps {
+			pane := report.Assignments[step.ID]
+			if pane == "" {
+				pane = "-"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", step.Order, step.ID, step.Status, pane, step.Name)
+		}
+		w.Flush()
+	}
+
+	if len(report.Tasks) > 0 {
+		fmt.Println("\n🗂️  Recent tasks")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, task := range report.Tasks {
+			fmt.Fprintf(w, "   %s\t%s\t%s\n", task.ID, task.Status, task.Title)
+		}
+		w.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/session"
+)
+
+// TaskCommand assigns a task to the AI team in the running session, or
+// manages the task queue with its add, list, cancel and run subcommands
+type TaskCommand struct {
+	args []string
+}
+
+func NewTaskCommand(args []string) *TaskCommand {
+	return &TaskCommand{
+		args: args,
+	}
+}
+
+func (c *TaskCommand) Execute(ctx context.Context) error {
+	// `task add|list|cancel|run` work on the task queue
+	if len(c.args) > 0 {
+		if newCommand, exists := queueCommands[c.args[0]]; exists {
+			return newCommand(c.args[1:]).Execute(ctx)
+		}
+	}
+
+	fs := flag.NewFlagSet("task", flag.ContinueOnError)
+	var mf managerFlags
+	mf.register(fs)
+	var input taskInput
+	input.register(fs)
+	dryRun := fs.Bool("dry-run", false, "Print the plan and step prompts without sending anything to tmux")
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+
+	req, err := input.request(fs, "claude-company task [flags] <description>")
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		manager, err := mf.newManager()
+		if err != nil {
+			return err
+		}
+		preview, err := manager.PreviewPlan(ctx, req)
+		if err != nil {
+			return err
+		}
+		printPlanPreview(preview)
+		return nil
+	}
+
+	manager, err := mf.newManager()
+	if err != nil {
+		return err
+	}
+	if err := requireSession(manager.SessionName); err != nil {
+		return err
+	}
+	return NewDeployCommandForRequest(req, manager).Execute(ctx)
+}
+
+// printPlanPreview shows the plan of a dry run: its steps, the batches they
+// run in, and the prompt each worker would receive
+func printPlanPreview(preview *session.PlanPreview) {
+	task, plan := preview.Task, preview.Plan
+
+	order := make(map[string]int, len(plan.Steps))
+	for _, step := range plan.Steps {
+		order[step.ID] = step.Order
+	}
+
+	fmt.Println("🧪 ドライラン: tmux ペインには何も送信しません")
+	fmt.Printf("🔄 タスク: %s (%s, %s)\n", task.Title, task.Type, task.Priority)
+	fmt.Printf("🗂️  プラン: %s, %dステップ, 見積もり %s\n", plan.Strategy, len(plan.Steps), plan.EstimatedTime.Round(time.Minute))
+
+	fmt.Println("\n📋 ステップ:")
+	for _, step := range plan.Steps {
+		deps := make([]string, 0, len(step.Dependencies))
+		for _, dep := range step.Dependencies {
+			deps = append(deps, fmt.Sprint(order[dep]))
+		}
+		line := fmt.Sprintf("   %d. %s [%s]", step.Order, step.Name, step.Type)
+		if len(deps) > 0 {
+			line += " ← " + strings.Join(deps, ", ")
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Println("\n🔀 依存グラフ (同じバッチのステップは並列に実行されます):")
+	for i, batch := range orchestrator.ExecutionBatches(plan.Steps) {
+		names := make([]string, 0, len(batch))
+		for _, step := range batch {
+			names = append(names, fmt.Sprintf("%d. %s", step.Order, step.Name))
+		}
+		fmt.Printf("   バッチ %d: %s\n", i+1, strings.Join(names, " | "))
+	}
+
+	for _, step := range plan.Steps {
+		fmt.Printf("\n──── %d. %s (%s) ────\n", step.Order, step.Name, step.ID)
+		fmt.Println(strings.TrimSpace(preview.Prompts[step.ID]))
+	}
+}
+
+// taskInput reads a task from the command line, a markdown spec file or
+// stdin. Specs may start with YAML front-matter (see orchestrator.ParseTaskSpec).
+type taskInput struct {
+	file  string
+	stdin bool
+}
+
+func (f *taskInput) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.file, "file", "", "Read the task from a markdown file with optional YAML front-matter")
+	fs.BoolVar(&f.stdin, "stdin", false, "Read the task from stdin (markdown with optional YAML front-matter)")
+}
+
+// request builds the task request from the parsed flags and arguments
+func (f *taskInput) request(fs *flag.FlagSet, usage string) (orchestrator.TaskRequest, error) {
+	taskDesc := strings.TrimSpace(strings.Join(fs.Args(), " "))
+
+	if f.file == "" && !f.stdin {
+		if taskDesc == "" {
+			return orchestrator.TaskRequest{}, exitError(ExitUsage, "task description is required: %s", usage)
+		}
+		return orchestrator.TaskRequest{
+			Type:        orchestrator.TaskTypeFeature,
+			Title:       taskTitle(taskDesc),
+			Description: taskDesc,
+			Priority:    orchestrator.TaskPriorityMedium,
+		}, nil
+	}
+
+	if f.file != "" && f.stdin {
+		return orchestrator.TaskRequest{}, exitError(ExitUsage, "--file and --stdin cannot be used together")
+	}
+	if taskDesc != "" {
+		return orchestrator.TaskRequest{}, exitError(ExitUsage, "a task description cannot be combined with --file or --stdin")
+	}
+
+	var data []byte
+	var err error
+	source := f.file
+	if f.stdin {
+		source = "stdin"
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(f.file)
+	}
+	if err != nil {
+		return orchestrator.TaskRequest{}, fmt.Errorf("failed to read task from %s: %w", source, err)
+	}
+
+	req, err := orchestrator.ParseTaskSpec(data)
+	if err != nil {
+		return orchestrator.TaskRequest{}, exitError(ExitUsage, "%s: %v", source, err)
+	}
+	if req.Title == "" {
+		req.Title = taskTitle(req.Description)
+	}
+	return req, nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"claude-company/internal/tui"
+)
+
+// UICommand assigns a task in orchestrator mode and follows its plan in a
+// full-screen dashboard where steps can be retried, cancelled or reassigned
+type UICommand struct {
+	args []string
+}
+
+func NewUICommand(args []string) *UICommand {
+	return &UICommand{
+		args: args,
+	}
+}
+
+func (c *UICommand) Execute(ctx context.Context) error {
+	fs := flag.NewFlagSet("ui", flag.ContinueOnError)
+	var mf managerFlags
+	mf.register(fs)
+	tailLines := fs.Int("tail", 3, "Lines of output shown for each worker pane")
+	var input taskInput
+	input.register(fs)
+	if err := parseFlags(fs, c.args); err != nil {
+		return err
+	}
+
+	req, err := input.request(fs, "claude-company ui [flags] <description>")
+	if err != nil {
+		return err
+	}
+
+	if err := tui.CheckTerminal(); err != nil {
+		return &ExitError{Code: ExitUsage, Err: err}
+	}
+	manager, err := mf.newManager()
+	if err != nil {
+		return err
+	}
+	if err := requireSession(manager.SessionName); err != nil {
+		return err
+	}
+	// Events are shown by the dashboard
+	manager.SetEventLogging(false)
+
+	plan, err := NewDeployCommandForRequest(req, manager).PreparePlan(ctx)
+	if err != nil {
+		return err
+	}
+
+	dashboard := tui.NewDashboard(manager, plan.ID, req.Title)
+	dashboard.TailLines = *tailLines
+	if err := dashboard.Run(ctx); err != nil {
+		return finishRun(ctx, manager, fmt.Errorf("plan %s: %w", plan.ID, err))
+	}
+
+	fmt.Printf("✅ タスク %s の全ステップが完了しました\n", plan.TaskID)
+	return nil
+}
@@ -11,6 +11,9 @@ type OrchestratorConfig struct {
 	Workers  WorkersConfig  `yaml:"workers"`
 	Session  SessionConfig  `yaml:"session"`
 	Defaults DefaultsConfig `yaml:"defaults"`
+
+	// Source is the file the configuration was loaded from, if any
+	Source string `yaml:"-"`
 }
 
 type ManagerConfig struct {
@@ -27,6 +30,13 @@ type WorkersConfig struct {
 	CoordinationMode string `yaml:"coordination_mode"`
 }
 
+// Worker layouts selectable with session.layout
+const (
+	LayoutTiled        = "tiled"         // workers tiled with the parent panes
+	LayoutMainVertical = "main-vertical" // parent pane on the left, workers stacked on the right
+	LayoutWindows      = "windows"       // one window per worker
+)
+
 type SessionConfig struct {
 	Name           string `yaml:"name"`
 	Layout         string `yaml:"layout"`
@@ -38,6 +48,7 @@ type SessionConfig struct {
 type DefaultsConfig struct {
 	WorkingDir  string `yaml:"working_dir"`
 	Shell       string `yaml:"shell"`
+	ClaudeCommand string `yaml:"claude_command"`
 	ClaudeFlags []string `yaml:"claude_flags"`
 	Language    string `yaml:"language"`
 }
@@ -56,9 +67,10 @@ func NewOrchestratorConfig() *OrchestratorConfig {
 			TaskTimeout:  1800,
 			CoordinationMode: "hierarchical",
 		},
+		// Session.Name is left empty so that Resolve derives it from the
+		// project directory
 		Session: SessionConfig{
-			Name:         "claude-squad",
-			Layout:       "tiled",
+			Layout:       LayoutTiled,
 			WindowPrefix: "work",
 			PanePrefix:   "claude",
 			AutoStartTmux: true,
@@ -66,6 +78,7 @@ func NewOrchestratorConfig() *OrchestratorConfig {
 		Defaults: DefaultsConfig{
 			WorkingDir:  ".",
 			Shell:       "/bin/bash",
+			ClaudeCommand: "claude",
 			ClaudeFlags: []string{"--dangerously-skip-permissions"},
 			Language:    "ja",
 		},
@@ -79,6 +92,8 @@ func (c *OrchestratorConfig) LoadFromFile(filePath string) error {
 
 func (c *OrchestratorConfig) GetConfigPath() (string, error) {
 	configPaths := []string{
+		".claude-company.yaml",
+		".claude-company.yml",
 		".claude/orchestrator.yaml",
 		".claude/orchestrator.yml",
 		"orchestrator.yaml",
@@ -108,8 +123,16 @@ func (c *OrchestratorConfig) Validate() error {
 	if c.Session.Name == "" {
 		return fmt.Errorf("session.name は必須です")
 	}
+	switch c.Session.Layout {
+	case LayoutTiled, LayoutMainVertical, LayoutWindows:
+	default:
+		return fmt.Errorf("session.layout は %s / %s / %s のいずれかである必要があります: %q", LayoutTiled, LayoutMainVertical, LayoutWindows, c.Session.Layout)
+	}
 	if c.Defaults.WorkingDir == "" {
 		return fmt.Errorf("defaults.working_dir は必須です")
 	}
+	if c.Defaults.ClaudeCommand == "" {
+		return fmt.Errorf("defaults.claude_command は必須です")
+	}
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ConfigEnvVar names the configuration file when no path is given explicitly
+const ConfigEnvVar = "CLAUDE_COMPANY_CONFIG"
+
+// Environment variables that override the configuration file
+const (
+	SessionEnvVar       = "CLAUDE_COMPANY_SESSION"
+	LayoutEnvVar        = "CLAUDE_COMPANY_LAYOUT"
+	MaxWorkersEnvVar    = "CLAUDE_COMPANY_MAX_WORKERS"
+	WorkerRolesEnvVar   = "CLAUDE_COMPANY_WORKER_ROLES"
+	TaskTimeoutEnvVar   = "CLAUDE_COMPANY_TASK_TIMEOUT"
+	ClaudeCommandEnvVar = "CLAUDE_COMPANY_CLAUDE_COMMAND"
+	ClaudeFlagsEnvVar   = "CLAUDE_COMPANY_CLAUDE_FLAGS"
+	LanguageEnvVar      = "CLAUDE_COMPANY_LANGUAGE"
+)
+
+// Resolve builds the effective configuration: the defaults, overlaid with the
+// configuration file and then with environment variables. The file is path,
+// or $CLAUDE_COMPANY_CONFIG, or the first one found by GetConfigPath; running
+// without any file is fine, but an explicitly named file must exist. When no
+// session name is configured it is derived from the current directory, so
+// that each project runs its own company.
+func Resolve(path string) (*OrchestratorConfig, error) {
+	c := NewOrchestratorConfig()
+
+	if path == "" {
+		path = os.Getenv(ConfigEnvVar)
+	}
+	if path == "" {
+		path, _ = c.GetConfigPath()
+	}
+	if path != "" {
+		if err := c.LoadFromFile(path); err != nil {
+			return nil, err
+		}
+		c.Source = path
+	}
+
+	if err := c.ApplyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	if c.Session.Name == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("作業ディレクトリを取得できません: %w", err)
+		}
+		c.Session.Name = ProjectSessionName(wd)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("設定が正しくありません: %w", err)
+	}
+	return c, nil
+}
+
+// ApplyEnv overrides settings from the CLAUDE_COMPANY_* environment variables
+func (c *OrchestratorConfig) ApplyEnv(lookup func(string) (string, bool)) error {
+	if value, ok := lookup(SessionEnvVar); ok {
+		c.Session.Name = value
+	}
+	if value, ok := lookup(LayoutEnvVar); ok {
+		c.Session.Layout = value
+	}
+	if value, ok := lookup(MaxWorkersEnvVar); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s は整数である必要があります: %q", MaxWorkersEnvVar, value)
+		}
+		c.Workers.MaxWorkers = n
+	}
+	if value, ok := lookup(WorkerRolesEnvVar); ok {
+		c.Workers.Roles = nil
+		for _, role := range strings.Split(value, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				c.Workers.Roles = append(c.Workers.Roles, role)
+			}
+		}
+	}
+	if value, ok := lookup(TaskTimeoutEnvVar); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s は秒数である必要があります: %q", TaskTimeoutEnvVar, value)
+		}
+		c.Workers.TaskTimeout = n
+	}
+	if value, ok := lookup(ClaudeCommandEnvVar); ok {
+		c.Defaults.ClaudeCommand = value
+	}
+	if value, ok := lookup(ClaudeFlagsEnvVar); ok {
+		c.Defaults.ClaudeFlags = strings.Fields(value)
+	}
+	if value, ok := lookup(LanguageEnvVar); ok {
+		c.Defaults.Language = value
+	}
+	return nil
+}
+
+// ClaudeCmd is the command line that starts Claude in a pane
+func (c *OrchestratorConfig) ClaudeCmd() string {
+	return strings.Join(append([]string{c.Defaults.ClaudeCommand}, c.Defaults.ClaudeFlags...), " ")
+}
+
+// sessionNameUnsafe matches characters tmux does not allow in session names
+// (. and :) along with anything awkward to type in a -t target
+var sessionNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// ProjectSessionName derives the session name of the project in dir,
+// e.g. "claude-my-repo" for /src/my-repo
+func ProjectSessionName(dir string) string {
+	name := strings.Trim(sessionNameUnsafe.ReplaceAllString(filepath.Base(dir), "-"), "-")
+	if name == "" {
+		return "claude-company"
+	}
+	return "claude-" + name
+}
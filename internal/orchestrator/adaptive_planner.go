@@ -512,4 +512,59 @@ func (ap *AdaptivePlanner) GetLearningInsights() map[string]interface{} {
 	insights["execution_log_size"] = len(ap.executionLog)
 	
 	return insights
-}
\ No newline at end of file
+}
+
+// PlanFromTaskPlan converts a TaskPlan into the Plan model used by the
+// adaptive planner, so executed TaskSteps can be evaluated and learned from
+func PlanFromTaskPlan(taskPlan *TaskPlan) *Plan {
+	now := time.Now()
+	plan := &Plan{
+		ID:           taskPlan.ID,
+		Name:         taskPlan.TaskID,
+		Steps:        make([]*Step, 0, len(taskPlan.Steps)),
+		Dependencies: make(map[string][]string),
+		CreatedAt:    taskPlan.CreatedAt,
+		UpdatedAt:    now,
+		Status:       PlanStatusActive,
+		Metadata: map[string]interface{}{
+			"task_id":  taskPlan.TaskID,
+			"strategy": taskPlan.Strategy,
+		},
+	}
+
+	for _, taskStep := range taskPlan.Steps {
+		plan.Steps = append(plan.Steps, &Step{
+			ID:           taskStep.ID,
+			Name:         taskStep.Name,
+			Description:  taskStep.Description,
+			Type:         taskStep.Type,
+			Status:       stepStatusFromTaskStatus(taskStep.Status),
+			Priority:     len(taskPlan.Steps) - taskStep.Order,
+			Dependencies: append([]string(nil), taskStep.Dependencies...),
+			MaxRetries:   3,
+			CreatedAt:    taskPlan.CreatedAt,
+			UpdatedAt:    now,
+			Metadata:     map[string]interface{}{"order": taskStep.Order},
+		})
+		for _, dep := range taskStep.Dependencies {
+			plan.Dependencies[dep] = append(plan.Dependencies[dep], taskStep.ID)
+		}
+	}
+
+	return plan
+}
+
+func stepStatusFromTaskStatus(status TaskStatus) StepStatus {
+	switch status {
+	case TaskStatusInProgress:
+		return StepStatusInProgress
+	case TaskStatusCompleted:
+		return StepStatusCompleted
+	case TaskStatusFailed:
+		return StepStatusFailed
+	case TaskStatusCancelled:
+		return StepStatusSkipped
+	default:
+		return StepStatusPending
+	}
+}
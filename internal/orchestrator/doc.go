@@ -30,17 +30,20 @@ Key Components:
    - Event-driven architecture for loose coupling
    - Pluggable event handlers and filters
    - Audit trail and logging
+   - InProcessEventBus with per-subscriber buffers and backpressure policies
 
 5. Storage:
    - Persistent task and plan storage
    - Event history and audit logs
    - Configuration management
    - Data consistency and integrity
+   - FileStorage (JSON documents) and SQLiteStorage (embedded SQL with migrations)
 
 Usage:
 
-	// Create a new orchestrator instance
-	orchestrator := orchestrator.New(config)
+	// Create a new orchestrator instance (event bus, storage and worker
+	// manager are optional and may be nil)
+	orchestrator := orchestrator.NewTaskOrchestrator(config, eventBus, storage, workerManager)
 	
 	// Start the orchestrator
 	err := orchestrator.Start(ctx)
@@ -0,0 +1,299 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BackpressurePolicy decides what Publish does when a subscriber's buffer is full
+type BackpressurePolicy string
+
+const (
+	// BackpressureDropNewest discards the event being published
+	BackpressureDropNewest BackpressurePolicy = "drop_newest"
+	// BackpressureDropOldest discards the oldest buffered event to make room
+	BackpressureDropOldest BackpressurePolicy = "drop_oldest"
+	// BackpressureBlock waits up to BlockTimeout for room, then drops the event
+	BackpressureBlock BackpressurePolicy = "block"
+)
+
+// InProcessEventBus is an EventBus that fans events out to buffered
+// per-subscriber channels within the current process.
+type InProcessEventBus struct {
+	mu            sync.RWMutex
+	config        EventBusConfig
+	subscriptions map[string]*subscription
+	filters       map[string]EventFilter
+	nextID        int
+	closed        bool
+	stats         EventBusStats
+}
+
+// EventBusConfig configures an InProcessEventBus
+type EventBusConfig struct {
+	BufferSize   int                `json:"buffer_size"`
+	Policy       BackpressurePolicy `json:"policy"`
+	BlockTimeout time.Duration      `json:"block_timeout"`
+}
+
+// EventBusStats counts delivered and dropped events
+type EventBusStats struct {
+	Published     int64 `json:"published"`
+	Filtered      int64 `json:"filtered"`
+	Delivered     int64 `json:"delivered"`
+	Dropped       int64 `json:"dropped"`
+	Subscriptions int   `json:"subscriptions"`
+}
+
+type subscription struct {
+	mu         sync.Mutex
+	id         string
+	eventTypes []TaskEventType
+	ch         chan TaskEvent
+	closed     bool
+}
+
+// NewInProcessEventBus creates a new in-process event bus
+func NewInProcessEventBus(config EventBusConfig) *InProcessEventBus {
+	if config.BufferSize <= 0 {
+		config.BufferSize = 256
+	}
+	if config.Policy == "" {
+		config.Policy = BackpressureBlock
+	}
+	if config.BlockTimeout <= 0 {
+		config.BlockTimeout = 100 * time.Millisecond
+	}
+
+	return &InProcessEventBus{
+		config:        config,
+		subscriptions: make(map[string]*subscription),
+		filters:       make(map[string]EventFilter),
+	}
+}
+
+// Publish delivers the event to every subscriber interested in its type.
+// When filters are registered, only events matching at least one are delivered.
+func (b *InProcessEventBus) Publish(ctx context.Context, event TaskEvent) error {
+	if event.ID == "" {
+		event.ID = generateEventID()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return fmt.Errorf("event bus is closed")
+	}
+	b.stats.Published++
+	if !b.passesFilters(event) {
+		b.stats.Filtered++
+		b.mu.Unlock()
+		return nil
+	}
+	targets := make([]*subscription, 0, len(b.subscriptions))
+	for _, sub := range b.subscriptions {
+		if len(sub.eventTypes) == 0 || containsValue(sub.eventTypes, event.Type) {
+			targets = append(targets, sub)
+		}
+	}
+	b.mu.Unlock()
+
+	var delivered, dropped int64
+	for _, sub := range targets {
+		ok, evicted := b.deliver(ctx, sub, event)
+		if ok {
+			delivered++
+		} else {
+			dropped++
+		}
+		if evicted {
+			dropped++
+		}
+	}
+
+	b.mu.Lock()
+	b.stats.Delivered += delivered
+	b.stats.Dropped += dropped
+	b.mu.Unlock()
+
+	return nil
+}
+
+// Subscribe returns a channel receiving events of the given types (all types
+// when empty). The subscription ends when ctx is done.
+func (b *InProcessEventBus) Subscribe(ctx context.Context, eventTypes []TaskEventType) (<-chan TaskEvent, error) {
+	_, ch, err := b.SubscribeWithID(ctx, eventTypes)
+	return ch, err
+}
+
+// SubscribeWithID is like Subscribe but also returns the subscription ID to
+// pass to Unsubscribe.
+func (b *InProcessEventBus) SubscribeWithID(ctx context.Context, eventTypes []TaskEventType) (string, <-chan TaskEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return "", nil, fmt.Errorf("event bus is closed")
+	}
+
+	b.nextID++
+	sub := &subscription{
+		id:         fmt.Sprintf("sub_%d", b.nextID),
+		eventTypes: append([]TaskEventType(nil), eventTypes...),
+		ch:         make(chan TaskEvent, b.config.BufferSize),
+	}
+	b.subscriptions[sub.id] = sub
+
+	if ctx != nil && ctx.Done() != nil {
+		go func() {
+			<-ctx.Done()
+			b.Unsubscribe(context.Background(), sub.id)
+		}()
+	}
+
+	return sub.id, sub.ch, nil
+}
+
+// Unsubscribe removes the subscription and closes its channel. Events already
+// buffered can still be received.
+func (b *InProcessEventBus) Unsubscribe(ctx context.Context, subscriptionID string) error {
+	b.mu.Lock()
+	sub, exists := b.subscriptions[subscriptionID]
+	if exists {
+		delete(b.subscriptions, subscriptionID)
+	}
+	b.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("subscription not found: %s", subscriptionID)
+	}
+
+	sub.close()
+	return nil
+}
+
+// AddFilter registers an allow-list filter. Filters are matched with
+// MatchEventFilter and apply to all subscribers.
+func (b *InProcessEventBus) AddFilter(ctx context.Context, filter EventFilter) error {
+	if filter.ID == "" {
+		return fmt.Errorf("filter ID is required")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.filters[filter.ID] = filter
+	return nil
+}
+
+func (b *InProcessEventBus) RemoveFilter(ctx context.Context, filterID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.filters[filterID]; !exists {
+		return fmt.Errorf("filter not found: %s", filterID)
+	}
+	delete(b.filters, filterID)
+	return nil
+}
+
+// Stats returns a snapshot of the bus counters
+func (b *InProcessEventBus) Stats() EventBusStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := b.stats
+	stats.Subscriptions = len(b.subscriptions)
+	return stats
+}
+
+// Close removes all subscriptions and rejects further publishes
+func (b *InProcessEventBus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	subs := b.subscriptions
+	b.subscriptions = make(map[string]*subscription)
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+	return nil
+}
+
+// passesFilters must be called with b.mu held
+func (b *InProcessEventBus) passesFilters(event TaskEvent) bool {
+	if len(b.filters) == 0 {
+		return true
+	}
+	for _, filter := range b.filters {
+		if MatchEventFilter(event, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver sends the event to one subscriber according to the backpressure
+// policy. evicted reports whether an older buffered event was discarded.
+func (b *InProcessEventBus) deliver(ctx context.Context, sub *subscription, event TaskEvent) (ok bool, evicted bool) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if sub.closed {
+		return false, false
+	}
+
+	select {
+	case sub.ch <- event:
+		return true, false
+	default:
+	}
+
+	switch b.config.Policy {
+	case BackpressureDropOldest:
+		select {
+		case <-sub.ch:
+			evicted = true
+		default:
+		}
+		select {
+		case sub.ch <- event:
+			return true, evicted
+		default:
+			return false, evicted
+		}
+	case BackpressureBlock:
+		timer := time.NewTimer(b.config.BlockTimeout)
+		defer timer.Stop()
+		select {
+		case sub.ch <- event:
+			return true, false
+		case <-timer.C:
+			return false, false
+		case <-ctx.Done():
+			return false, false
+		}
+	default:
+		return false, false
+	}
+}
+
+func (s *subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
@@ -0,0 +1,476 @@
+package orchestrator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileStorage is a Storage implementation that persists tasks, plans and
+// workers as JSON documents and events as a JSON Lines log under a directory.
+//
+// Layout:
+//
+//	<base>/tasks/<task-id>.json
+//	<base>/plans/<plan-id>.json
+//	<base>/workers/<worker-id>.json
+//	<base>/events.jsonl
+type FileStorage struct {
+	mu     sync.RWMutex
+	config FileStorageConfig
+}
+
+// FileStorageConfig configures a FileStorage
+type FileStorageConfig struct {
+	BaseDir string `json:"base_dir"`
+	// Retention is how long finished tasks, offline workers and their events
+	// are kept before Cleanup removes them.
+	Retention time.Duration `json:"retention"`
+}
+
+const (
+	fileStorageTasksDir   = "tasks"
+	fileStoragePlansDir   = "plans"
+	fileStorageWorkersDir = "workers"
+	fileStorageEventsFile = "events.jsonl"
+)
+
+// NewFileStorage creates a file-backed storage rooted at config.BaseDir
+func NewFileStorage(config FileStorageConfig) (*FileStorage, error) {
+	if config.BaseDir == "" {
+		return nil, fmt.Errorf("storage base directory is required")
+	}
+	if config.Retention <= 0 {
+		config.Retention = 7 * 24 * time.Hour
+	}
+
+	for _, dir := range []string{fileStorageTasksDir, fileStoragePlansDir, fileStorageWorkersDir} {
+		if err := os.MkdirAll(filepath.Join(config.BaseDir, dir), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create storage directory: %w", err)
+		}
+	}
+
+	return &FileStorage{config: config}, nil
+}
+
+// BaseDir returns the storage root directory
+func (fs *FileStorage) BaseDir() string {
+	return fs.config.BaseDir
+}
+
+func (fs *FileStorage) SaveTask(ctx context.Context, task *Task) error {
+	if task == nil || task.ID == "" {
+		return fmt.Errorf("task must have an ID")
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.writeDocument(fileStorageTasksDir, task.ID, task)
+}
+
+func (fs *FileStorage) LoadTask(ctx context.Context, taskID string) (*Task, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	var task Task
+	if err := fs.readDocument(fileStorageTasksDir, taskID, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (fs *FileStorage) ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	ids, err := fs.listDocuments(fileStorageTasksDir)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*Task, 0, len(ids))
+	for _, id := range ids {
+		var task Task
+		if err := fs.readDocument(fileStorageTasksDir, id, &task); err != nil {
+			continue
+		}
+		tasks = append(tasks, &task)
+	}
+
+	return ApplyTaskFilter(tasks, filter), nil
+}
+
+func (fs *FileStorage) DeleteTask(ctx context.Context, taskID string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.removeDocument(fileStorageTasksDir, taskID)
+}
+
+func (fs *FileStorage) SavePlan(ctx context.Context, plan *TaskPlan) error {
+	if plan == nil || plan.ID == "" {
+		return fmt.Errorf("plan must have an ID")
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.writeDocument(fileStoragePlansDir, plan.ID, plan)
+}
+
+func (fs *FileStorage) LoadPlan(ctx context.Context, planID string) (*TaskPlan, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	var plan TaskPlan
+	if err := fs.readDocument(fileStoragePlansDir, planID, &plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (fs *FileStorage) DeletePlan(ctx context.Context, planID string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.removeDocument(fileStoragePlansDir, planID)
+}
+
+func (fs *FileStorage) SaveWorker(ctx context.Context, worker *Worker) error {
+	if worker == nil || worker.ID == "" {
+		return fmt.Errorf("worker must have an ID")
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.writeDocument(fileStorageWorkersDir, worker.ID, worker)
+}
+
+func (fs *FileStorage) LoadWorker(ctx context.Context, workerID string) (*Worker, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	var worker Worker
+	if err := fs.readDocument(fileStorageWorkersDir, workerID, &worker); err != nil {
+		return nil, err
+	}
+	return &worker, nil
+}
+
+func (fs *FileStorage) ListWorkers(ctx context.Context) ([]*Worker, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	ids, err := fs.listDocuments(fileStorageWorkersDir)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := make([]*Worker, 0, len(ids))
+	for _, id := range ids {
+		var worker Worker
+		if err := fs.readDocument(fileStorageWorkersDir, id, &worker); err != nil {
+			continue
+		}
+		workers = append(workers, &worker)
+	}
+
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].ID < workers[j].ID
+	})
+	return workers, nil
+}
+
+func (fs *FileStorage) DeleteWorker(ctx context.Context, workerID string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.removeDocument(fileStorageWorkersDir, workerID)
+}
+
+func (fs *FileStorage) SaveEvent(ctx context.Context, event *TaskEvent) error {
+	if event == nil {
+		return fmt.Errorf("event is nil")
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	file, err := os.OpenFile(fs.eventsPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append event: %w", err)
+	}
+	return nil
+}
+
+func (fs *FileStorage) ListEvents(ctx context.Context, filter EventFilter) ([]*TaskEvent, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	events, err := fs.readEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*TaskEvent, 0, len(events))
+	for _, event := range events {
+		if MatchEventFilter(*event, filter) {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+// Cleanup removes finished tasks (with their plans and events) and offline
+// workers whose last update is older than the configured retention.
+func (fs *FileStorage) Cleanup(ctx context.Context) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	cutoff := time.Now().Add(-fs.config.Retention)
+	removedTasks := make(map[string]bool)
+
+	taskIDs, err := fs.listDocuments(fileStorageTasksDir)
+	if err != nil {
+		return err
+	}
+	for _, id := range taskIDs {
+		var task Task
+		if err := fs.readDocument(fileStorageTasksDir, id, &task); err != nil {
+			continue
+		}
+		if !isTerminalTaskStatus(task.Status) || task.UpdatedAt.After(cutoff) {
+			continue
+		}
+		if task.Plan != nil {
+			fs.removeDocument(fileStoragePlansDir, task.Plan.ID)
+		}
+		if err := fs.removeDocument(fileStorageTasksDir, id); err != nil {
+			return err
+		}
+		removedTasks[id] = true
+	}
+
+	workerIDs, err := fs.listDocuments(fileStorageWorkersDir)
+	if err != nil {
+		return err
+	}
+	for _, id := range workerIDs {
+		var worker Worker
+		if err := fs.readDocument(fileStorageWorkersDir, id, &worker); err != nil {
+			continue
+		}
+		if worker.Status == WorkerStatusOffline && worker.LastSeen.Before(cutoff) {
+			fs.removeDocument(fileStorageWorkersDir, id)
+		}
+	}
+
+	events, err := fs.readEvents()
+	if err != nil {
+		return err
+	}
+	kept := make([]*TaskEvent, 0, len(events))
+	for _, event := range events {
+		if removedTasks[event.TaskID] || (event.TaskID == "" && event.Timestamp.Before(cutoff)) {
+			continue
+		}
+		kept = append(kept, event)
+	}
+	if len(kept) == len(events) {
+		return nil
+	}
+	return fs.rewriteEvents(kept)
+}
+
+func (fs *FileStorage) documentPath(kind, id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return "", fmt.Errorf("invalid document ID: %q", id)
+	}
+	return filepath.Join(fs.config.BaseDir, kind, id+".json"), nil
+}
+
+func (fs *FileStorage) eventsPath() string {
+	return filepath.Join(fs.config.BaseDir, fileStorageEventsFile)
+}
+
+func (fs *FileStorage) writeDocument(kind, id string, v any) error {
+	path, err := fs.documentPath(kind, id)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s %s: %w", kind, id, err)
+	}
+
+	// Write to a temporary file first so readers never see a partial document
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+id+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s %s: %w", kind, id, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s %s: %w", kind, id, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save %s %s: %w", kind, id, err)
+	}
+	return nil
+}
+
+func (fs *FileStorage) readDocument(kind, id string, v any) error {
+	path, err := fs.documentPath(kind, id)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s not found: %s", strings.TrimSuffix(kind, "s"), id)
+		}
+		return fmt.Errorf("failed to read %s %s: %w", kind, id, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s %s: %w", kind, id, err)
+	}
+	return nil
+}
+
+func (fs *FileStorage) removeDocument(kind, id string) error {
+	path, err := fs.documentPath(kind, id)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s not found: %s", strings.TrimSuffix(kind, "s"), id)
+		}
+		return fmt.Errorf("failed to delete %s %s: %w", kind, id, err)
+	}
+	return nil
+}
+
+func (fs *FileStorage) listDocuments(kind string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(fs.config.BaseDir, kind))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, ".json"))
+	}
+	return ids, nil
+}
+
+func (fs *FileStorage) readEvents() ([]*TaskEvent, error) {
+	file, err := os.Open(fs.eventsPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*TaskEvent{}, nil
+		}
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer file.Close()
+
+	events := make([]*TaskEvent, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var event TaskEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			// Skip a torn trailing line left by an interrupted write
+			continue
+		}
+		events = append(events, &event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event log: %w", err)
+	}
+	return events, nil
+}
+
+func (fs *FileStorage) rewriteEvents(events []*TaskEvent) error {
+	tmp, err := os.CreateTemp(fs.config.BaseDir, ".events.*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		writer.Write(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write event log: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write event log: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), fs.eventsPath())
+}
+
+// MatchEventFilter reports whether an event satisfies a filter. Empty filter
+// fields match everything; Conditions compare against event.Data values.
+func MatchEventFilter(event TaskEvent, filter EventFilter) bool {
+	if len(filter.EventTypes) > 0 && !containsValue(filter.EventTypes, event.Type) {
+		return false
+	}
+	if len(filter.TaskIDs) > 0 && !containsValue(filter.TaskIDs, event.TaskID) {
+		return false
+	}
+	for key, expected := range filter.Conditions {
+		actual, exists := event.Data[key]
+		if !exists || fmt.Sprint(actual) != fmt.Sprint(expected) {
+			return false
+		}
+	}
+	return true
+}
+
+func isTerminalTaskStatus(status TaskStatus) bool {
+	return status == TaskStatusCompleted || status == TaskStatusFailed || status == TaskStatusCancelled
+}
@@ -0,0 +1,768 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Version is reported by Orchestrator.Status
+const Version = "0.1.0"
+
+// TaskOrchestrator is the default Orchestrator implementation. It wires the
+// TaskPlanManager, StepManager and ParallelExecutor together and delegates
+// worker assignment to an optional WorkerManager.
+type TaskOrchestrator struct {
+	mu               sync.RWMutex
+	config           OrchestratorConfig
+	tasks            map[string]*Task
+	workers          map[string]*Worker
+	executions       map[string]context.CancelFunc // planID -> cancel
+	eventBus         EventBus
+	storage          Storage
+	workerManager    WorkerManager
+	planner          TaskPlanner
+	stepManager      *StepManager
+	taskPlanManager  *TaskPlanManager
+	parallelExecutor *ParallelExecutor
+	queue            *TaskQueue
+	running          bool
+	startedAt        time.Time
+	monitorCancel    context.CancelFunc
+	persisted        chan struct{} // closed when persistEvents has saved every event
+}
+
+// NewTaskOrchestrator creates an orchestrator. eventBus, storage and
+// workerManager may be nil, in which case the related features are disabled.
+func NewTaskOrchestrator(config OrchestratorConfig, eventBus EventBus, storage Storage, workerManager WorkerManager) *TaskOrchestrator {
+	if config.MaxConcurrentTasks <= 0 {
+		config.MaxConcurrentTasks = 5
+	}
+	if config.TaskTimeout <= 0 {
+		config.TaskTimeout = 30 * time.Minute
+	}
+	if config.RetryPolicy.InitialBackoff <= 0 {
+		config.RetryPolicy.InitialBackoff = 1 * time.Second
+	}
+	if config.RetryPolicy.MaxBackoff <= 0 {
+		config.RetryPolicy.MaxBackoff = 30 * time.Second
+	}
+	if config.RetryPolicy.BackoffFactor <= 0 {
+		config.RetryPolicy.BackoffFactor = 2.0
+	}
+
+	stepManager := NewStepManager(eventBus, storage, StepManagerConfig{
+		MaxConcurrentSteps: config.MaxConcurrentTasks,
+		StepTimeout:        config.TaskTimeout,
+		RetryPolicy:        config.RetryPolicy,
+		ExecutorPoolSize:   config.MaxConcurrentTasks,
+	})
+
+	var queue *TaskQueue
+	if storage != nil {
+		queue = NewTaskQueue(storage)
+	}
+
+	return &TaskOrchestrator{
+		config:          config,
+		tasks:           make(map[string]*Task),
+		workers:         make(map[string]*Worker),
+		executions:      make(map[string]context.CancelFunc),
+		eventBus:        eventBus,
+		storage:         storage,
+		workerManager:   workerManager,
+		planner:         NewDefaultTaskPlanner(),
+		stepManager:     stepManager,
+		taskPlanManager: NewTaskPlanManager(eventBus, storage, stepManager),
+		parallelExecutor: NewParallelExecutor(ParallelExecutorConfig{
+			MaxConcurrentJobs: config.MaxConcurrentTasks,
+			DefaultJobTimeout: config.TaskTimeout,
+			RetryPolicy:       config.RetryPolicy,
+		}, eventBus),
+		queue: queue,
+	}
+}
+
+// SetPlanner replaces the planner used by CreatePlan
+func (o *TaskOrchestrator) SetPlanner(planner TaskPlanner) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.planner = planner
+}
+
+// StepManager returns the step manager used for plan execution
+func (o *TaskOrchestrator) StepManager() *StepManager {
+	return o.stepManager
+}
+
+// TaskPlanManager returns the plan manager used for plan execution
+func (o *TaskOrchestrator) TaskPlanManager() *TaskPlanManager {
+	return o.taskPlanManager
+}
+
+// Queue returns the task queue, or nil when there is no storage
+func (o *TaskOrchestrator) Queue() *TaskQueue {
+	return o.queue
+}
+
+// ParallelExecutor returns the executor used for job-level parallelism
+func (o *TaskOrchestrator) ParallelExecutor() *ParallelExecutor {
+	return o.parallelExecutor
+}
+
+func (o *TaskOrchestrator) CreateTask(ctx context.Context, req TaskRequest) (*TaskResponse, error) {
+	task, err := NewTask(req)
+	if err != nil {
+		return nil, err
+	}
+	now := task.CreatedAt
+
+	o.mu.Lock()
+	o.tasks[task.ID] = task
+	o.mu.Unlock()
+
+	if o.storage != nil {
+		if err := o.storage.SaveTask(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to save task: %w", err)
+		}
+	}
+
+	o.publish(ctx, TaskEvent{
+		ID:        generateEventID(),
+		TaskID:    task.ID,
+		Type:      TaskEventCreated,
+		Timestamp: now,
+		Data: map[string]any{
+			"title":    task.Title,
+			"type":     task.Type,
+			"priority": task.Priority,
+		},
+	})
+
+	return &TaskResponse{
+		TaskID:  task.ID,
+		Status:  task.Status,
+		Message: "task created",
+	}, nil
+}
+
+func (o *TaskOrchestrator) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	o.mu.RLock()
+	task, exists := o.tasks[taskID]
+	o.mu.RUnlock()
+	if exists {
+		return task, nil
+	}
+
+	if o.storage != nil {
+		loaded, err := o.storage.LoadTask(ctx, taskID)
+		if err == nil {
+			o.mu.Lock()
+			o.tasks[taskID] = loaded
+			o.mu.Unlock()
+			return loaded, nil
+		}
+	}
+
+	return nil, fmt.Errorf("task not found: %s", taskID)
+}
+
+func (o *TaskOrchestrator) ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error) {
+	if o.storage != nil {
+		return o.storage.ListTasks(ctx, filter)
+	}
+
+	o.mu.RLock()
+	tasks := make([]*Task, 0, len(o.tasks))
+	for _, task := range o.tasks {
+		tasks = append(tasks, task)
+	}
+	o.mu.RUnlock()
+
+	return ApplyTaskFilter(tasks, filter), nil
+}
+
+func (o *TaskOrchestrator) UpdateTask(ctx context.Context, taskID string, updates TaskUpdate) error {
+	task, err := o.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	if updates.Title != nil {
+		task.Title = *updates.Title
+	}
+	if updates.Description != nil {
+		task.Description = *updates.Description
+	}
+	if updates.Status != nil {
+		o.setTaskStatus(task, *updates.Status)
+	}
+	if updates.Priority != nil {
+		task.Priority = *updates.Priority
+	}
+	if updates.Metadata != nil {
+		if task.Context.Metadata == nil {
+			task.Context.Metadata = make(map[string]any)
+		}
+		for k, v := range updates.Metadata {
+			task.Context.Metadata[k] = v
+		}
+	}
+	task.UpdatedAt = time.Now()
+	o.mu.Unlock()
+
+	if o.storage != nil {
+		if err := o.storage.SaveTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to save task: %w", err)
+		}
+	}
+
+	o.publish(ctx, TaskEvent{
+		ID:        generateEventID(),
+		TaskID:    task.ID,
+		Type:      TaskEventProgress,
+		Timestamp: time.Now(),
+		Data: map[string]any{
+			"status": task.Status,
+		},
+	})
+
+	return nil
+}
+
+func (o *TaskOrchestrator) CancelTask(ctx context.Context, taskID string) error {
+	task, err := o.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	if task.Plan != nil {
+		if cancel, exists := o.executions[task.Plan.ID]; exists {
+			cancel()
+		}
+	}
+	o.setTaskStatus(task, TaskStatusCancelled)
+	o.mu.Unlock()
+
+	if o.storage != nil {
+		if err := o.storage.SaveTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to save task: %w", err)
+		}
+	}
+
+	o.publish(ctx, TaskEvent{
+		ID:        generateEventID(),
+		TaskID:    task.ID,
+		Type:      TaskEventCancelled,
+		Timestamp: time.Now(),
+		Data:      map[string]any{},
+	})
+
+	return nil
+}
+
+func (o *TaskOrchestrator) DeleteTask(ctx context.Context, taskID string) error {
+	task, err := o.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if task.Status == TaskStatusInProgress {
+		return fmt.Errorf("task %s is in progress, cancel it first", taskID)
+	}
+
+	o.mu.Lock()
+	delete(o.tasks, taskID)
+	o.mu.Unlock()
+
+	if task.Plan != nil {
+		o.taskPlanManager.DeletePlan(ctx, task.Plan.ID)
+	}
+
+	if o.storage != nil {
+		if err := o.storage.DeleteTask(ctx, taskID); err != nil {
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (o *TaskOrchestrator) CreatePlan(ctx context.Context, taskID string) (*TaskPlan, error) {
+	task, err := o.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.RLock()
+	planner := o.planner
+	o.mu.RUnlock()
+
+	analysis, err := planner.AnalyzeTask(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze task: %w", err)
+	}
+
+	plan, err := planner.CreatePlan(ctx, task, analysis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plan: %w", err)
+	}
+
+	if err := planner.ValidatePlan(ctx, plan); err != nil {
+		return nil, fmt.Errorf("invalid plan: %w", err)
+	}
+
+	if err := o.taskPlanManager.CreatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	task.Plan = plan
+	task.UpdatedAt = time.Now()
+	o.mu.Unlock()
+
+	if o.storage != nil {
+		if err := o.storage.SaveTask(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to save task: %w", err)
+		}
+	}
+
+	return plan, nil
+}
+
+func (o *TaskOrchestrator) UpdatePlan(ctx context.Context, planID string, updates PlanUpdate) error {
+	return o.taskPlanManager.UpdatePlan(ctx, planID, updates)
+}
+
+func (o *TaskOrchestrator) ExecutePlan(ctx context.Context, planID string) error {
+	o.mu.RLock()
+	running := o.running
+	o.mu.RUnlock()
+	if !running {
+		return fmt.Errorf("orchestrator is not running")
+	}
+
+	plan, err := o.taskPlanManager.GetPlan(ctx, planID)
+	if err != nil {
+		return err
+	}
+
+	task, err := o.GetTask(ctx, plan.TaskID)
+	if err != nil {
+		return err
+	}
+
+	execCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	o.mu.Lock()
+	if _, exists := o.executions[planID]; exists {
+		o.mu.Unlock()
+		return fmt.Errorf("plan %s is already executing", planID)
+	}
+	o.executions[planID] = cancel
+	o.setTaskStatus(task, TaskStatusInProgress)
+	o.mu.Unlock()
+
+	defer func() {
+		o.mu.Lock()
+		delete(o.executions, planID)
+		o.mu.Unlock()
+	}()
+
+	if o.storage != nil {
+		o.storage.SaveTask(ctx, task)
+	}
+
+	executeErr := o.taskPlanManager.ExecutePlan(execCtx, planID)
+
+	o.mu.Lock()
+	switch {
+	case task.Status == TaskStatusCancelled:
+	case errors.Is(context.Cause(ctx), ErrShutdown):
+		// Still in progress; the plan is continued by resume
+	case executeErr != nil:
+		o.setTaskStatus(task, TaskStatusFailed)
+	default:
+		o.setTaskStatus(task, TaskStatusCompleted)
+	}
+	o.mu.Unlock()
+
+	if o.storage != nil {
+		if err := o.storage.SaveTask(context.WithoutCancel(ctx), task); err != nil && executeErr == nil {
+			return fmt.Errorf("failed to save task: %w", err)
+		}
+	}
+
+	return executeErr
+}
+
+func (o *TaskOrchestrator) RegisterWorker(ctx context.Context, worker Worker) error {
+	if worker.ID == "" {
+		return fmt.Errorf("worker ID is required")
+	}
+	if worker.Status == "" {
+		worker.Status = WorkerStatusIdle
+	}
+	worker.LastSeen = time.Now()
+
+	o.mu.Lock()
+	o.workers[worker.ID] = &worker
+	o.mu.Unlock()
+
+	if o.storage != nil {
+		if err := o.storage.SaveWorker(ctx, &worker); err != nil {
+			return fmt.Errorf("failed to save worker: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (o *TaskOrchestrator) UnregisterWorker(ctx context.Context, workerID string) error {
+	o.mu.Lock()
+	_, exists := o.workers[workerID]
+	delete(o.workers, workerID)
+	o.mu.Unlock()
+
+	if o.workerManager != nil {
+		if err := o.workerManager.RemoveWorker(ctx, workerID); err == nil {
+			exists = true
+		}
+	}
+
+	if !exists {
+		return fmt.Errorf("worker not found: %s", workerID)
+	}
+
+	if o.storage != nil {
+		if err := o.storage.DeleteWorker(ctx, workerID); err != nil {
+			return fmt.Errorf("failed to delete worker: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (o *TaskOrchestrator) ListWorkers(ctx context.Context) ([]*Worker, error) {
+	o.mu.RLock()
+	workers := make([]*Worker, 0, len(o.workers))
+	for _, worker := range o.workers {
+		workers = append(workers, worker)
+	}
+	o.mu.RUnlock()
+
+	if len(workers) == 0 && o.storage != nil {
+		return o.storage.ListWorkers(ctx)
+	}
+
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].ID < workers[j].ID
+	})
+	return workers, nil
+}
+
+func (o *TaskOrchestrator) AssignTask(ctx context.Context, taskID string, workerID string) error {
+	if _, err := o.GetTask(ctx, taskID); err != nil {
+		return err
+	}
+
+	if o.workerManager != nil {
+		return o.workerManager.AssignTask(ctx, workerID, taskID)
+	}
+
+	o.mu.Lock()
+	worker, exists := o.workers[workerID]
+	if !exists {
+		o.mu.Unlock()
+		return fmt.Errorf("worker not found: %s", workerID)
+	}
+	if worker.Status != WorkerStatusIdle {
+		o.mu.Unlock()
+		return fmt.Errorf("worker %s is not idle: %s", workerID, worker.Status)
+	}
+	worker.Status = WorkerStatusBusy
+	worker.CurrentTask = &taskID
+	worker.LastSeen = time.Now()
+	o.mu.Unlock()
+
+	if o.storage != nil {
+		if err := o.storage.SaveWorker(ctx, worker); err != nil {
+			return fmt.Errorf("failed to save worker: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (o *TaskOrchestrator) Subscribe(ctx context.Context, eventTypes []TaskEventType) (<-chan TaskEvent, error) {
+	if o.eventBus == nil {
+		return nil, fmt.Errorf("event bus is not configured")
+	}
+	return o.eventBus.Subscribe(ctx, eventTypes)
+}
+
+func (o *TaskOrchestrator) PublishEvent(ctx context.Context, event TaskEvent) error {
+	if event.ID == "" {
+		event.ID = generateEventID()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	if o.eventBus == nil {
+		return fmt.Errorf("event bus is not configured")
+	}
+	return o.eventBus.Publish(ctx, event)
+}
+
+func (o *TaskOrchestrator) Start(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.running {
+		return nil
+	}
+
+	o.running = true
+	o.startedAt = time.Now()
+
+	monitorCtx, cancel := context.WithCancel(context.Background())
+	o.monitorCancel = cancel
+
+	if o.workerManager != nil {
+		go o.workerManager.MonitorWorkers(monitorCtx)
+	}
+
+	// Record every published event in storage as an audit trail
+	if o.eventBus != nil && o.storage != nil {
+		events, err := o.eventBus.Subscribe(monitorCtx, nil)
+		if err != nil {
+			cancel()
+			o.running = false
+			return fmt.Errorf("failed to subscribe to events: %w", err)
+		}
+		o.persisted = make(chan struct{})
+		go o.persistEvents(events, o.persisted)
+	}
+
+	return nil
+}
+
+// persistEvents saves events until the subscription channel is closed
+func (o *TaskOrchestrator) persistEvents(events <-chan TaskEvent, done chan<- struct{}) {
+	defer close(done)
+	for event := range events {
+		event := event
+		o.storage.SaveEvent(context.Background(), &event)
+	}
+}
+
+func (o *TaskOrchestrator) Stop(ctx context.Context) error {
+	o.mu.Lock()
+	if !o.running {
+		o.mu.Unlock()
+		return nil
+	}
+	o.running = false
+
+	for _, cancel := range o.executions {
+		cancel()
+	}
+	monitorCancel, persisted := o.monitorCancel, o.persisted
+	o.monitorCancel, o.persisted = nil, nil
+	o.mu.Unlock()
+
+	if err := o.stepManager.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown step manager: %w", err)
+	}
+	if err := o.parallelExecutor.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown parallel executor: %w", err)
+	}
+
+	// Stop monitoring only now so that the events published while the steps
+	// wound down are still saved, then wait for them to be written
+	if monitorCancel != nil {
+		monitorCancel()
+	}
+	if persisted != nil {
+		select {
+		case <-persisted:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to flush events: %w", ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+func (o *TaskOrchestrator) Status(ctx context.Context) (*SystemStatus, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	status := &SystemStatus{
+		Version: Version,
+		Health: HealthStatus{
+			Overall:    "stopped",
+			Components: make(map[string]string),
+		},
+	}
+
+	if o.running {
+		status.Uptime = int64(time.Since(o.startedAt).Seconds())
+		status.Health.Overall = "healthy"
+	}
+
+	for _, task := range o.tasks {
+		if task.Status == TaskStatusInProgress {
+			status.ActiveTasks++
+		}
+	}
+
+	for _, worker := range o.workers {
+		if worker.Status != WorkerStatusOffline {
+			status.ActiveWorkers++
+		}
+	}
+
+	metrics := o.parallelExecutor.GetMetrics(ctx)
+	if o.config.MaxConcurrentTasks > 0 {
+		status.SystemLoad.CPU = float64(metrics.CurrentConcurrentJobs) / float64(o.config.MaxConcurrentTasks)
+	}
+
+	status.Health.Components["step_manager"] = componentState(o.running)
+	status.Health.Components["parallel_executor"] = componentState(o.running)
+	status.Health.Components["event_bus"] = componentState(o.eventBus != nil)
+	status.Health.Components["storage"] = componentState(o.storage != nil)
+	status.Health.Components["worker_manager"] = componentState(o.workerManager != nil)
+
+	return status, nil
+}
+
+// setTaskStatus must be called with o.mu held
+func (o *TaskOrchestrator) setTaskStatus(task *Task, status TaskStatus) {
+	task.Status = status
+	task.UpdatedAt = time.Now()
+	if status == TaskStatusCompleted || status == TaskStatusFailed || status == TaskStatusCancelled {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+}
+
+func (o *TaskOrchestrator) publish(ctx context.Context, event TaskEvent) {
+	if o.eventBus != nil {
+		o.eventBus.Publish(ctx, event)
+	}
+}
+
+// ApplyTaskFilter filters, sorts (newest first) and paginates tasks
+func ApplyTaskFilter(tasks []*Task, filter TaskFilter) []*Task {
+	var createdAfter, createdBefore time.Time
+	if filter.CreatedAfter != nil {
+		createdAfter, _ = time.Parse(time.RFC3339, *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		createdBefore, _ = time.Parse(time.RFC3339, *filter.CreatedBefore)
+	}
+
+	result := make([]*Task, 0, len(tasks))
+	for _, task := range tasks {
+		if len(filter.Status) > 0 && !containsValue(filter.Status, task.Status) {
+			continue
+		}
+		if len(filter.Type) > 0 && !containsValue(filter.Type, task.Type) {
+			continue
+		}
+		if len(filter.Priority) > 0 && !containsValue(filter.Priority, task.Priority) {
+			continue
+		}
+		if !createdAfter.IsZero() && !task.CreatedAt.After(createdAfter) {
+			continue
+		}
+		if !createdBefore.IsZero() && !task.CreatedAt.Before(createdBefore) {
+			continue
+		}
+		result = append(result, task)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(result) {
+			return []*Task{}
+		}
+		result = result[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(result) {
+		result = result[:filter.Limit]
+	}
+
+	return result
+}
+
+func containsValue[T comparable](values []T, target T) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func componentState(ok bool) string {
+	if ok {
+		return "running"
+	}
+	return "disabled"
+}
+
+// NewTask builds a pending task from a request, filling in defaults
+func NewTask(req TaskRequest) (*Task, error) {
+	if strings.TrimSpace(req.Title) == "" && strings.TrimSpace(req.Description) == "" {
+		return nil, fmt.Errorf("task title or description is required")
+	}
+
+	if req.Type == "" {
+		req.Type = TaskTypeFeature
+	}
+	if req.Priority == "" {
+		req.Priority = TaskPriorityMedium
+	}
+	if req.Title == "" {
+		req.Title = req.Description
+	}
+
+	projectPath := req.ProjectPath
+	if projectPath == "" {
+		projectPath, _ = os.Getwd()
+	}
+	now := time.Now()
+	return &Task{
+		ID:          generateTaskID(),
+		Type:        req.Type,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      TaskStatusPending,
+		Priority:    req.Priority,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Context: TaskContext{
+			ProjectPath: projectPath,
+			Branch:      req.Branch,
+			Environment: map[string]string{},
+			Metadata:    req.Metadata,
+		},
+	}, nil
+}
+
+func generateTaskID() string {
+	return fmt.Sprintf("task_%d", time.Now().UnixNano())
+}
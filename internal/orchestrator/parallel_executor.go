@@ -459,8 +459,16 @@ func (pe *ParallelExecutor) GetMetrics(ctx context.Context) *ExecutorMetrics {
 	pe.metrics.mu.RLock()
 	defer pe.metrics.mu.RUnlock()
 
-	metricsCopy := *pe.metrics
-	return &metricsCopy
+	return &ExecutorMetrics{
+		TotalJobsExecuted:     pe.metrics.TotalJobsExecuted,
+		SuccessfulJobs:        pe.metrics.SuccessfulJobs,
+		FailedJobs:            pe.metrics.FailedJobs,
+		CancelledJobs:         pe.metrics.CancelledJobs,
+		AvgExecutionTime:      pe.metrics.AvgExecutionTime,
+		CurrentConcurrentJobs: pe.metrics.CurrentConcurrentJobs,
+		PeakConcurrentJobs:    pe.metrics.PeakConcurrentJobs,
+		LastUpdateTime:        pe.metrics.LastUpdateTime,
+	}
 }
 
 func (pe *ParallelExecutor) updateMetrics(success bool, duration time.Duration) {
@@ -485,17 +493,22 @@ func (pe *ParallelExecutor) updateMetrics(success bool, duration time.Duration)
 	pe.metrics.LastUpdateTime = time.Now()
 }
 
+// Shutdown cancels the active jobs and waits for them to finish. Running jobs
+// update activeJobs when they end, so pe.mu must not be held while waiting.
 func (pe *ParallelExecutor) Shutdown(ctx context.Context) error {
 	pe.mu.Lock()
-	defer pe.mu.Unlock()
-
 	for _, job := range pe.activeJobs {
 		if job.Cancel != nil {
 			job.Cancel()
 		}
 	}
 
-	close(pe.executionPool.shutdown)
+	select {
+	case <-pe.executionPool.shutdown:
+	default:
+		close(pe.executionPool.shutdown)
+	}
+	pe.mu.Unlock()
 
 	done := make(chan struct{})
 	go func() {
@@ -573,6 +573,24 @@ func (pa *PlanAdjuster) AddAdjustmentRule(rule AdjustmentRule) {
 	})
 }
 
+// RetryTaskStep returns a failed or cancelled step of a task plan to pending
+// so that it is executed again, e.g. when the operator asks for a retry
+func (pa *PlanAdjuster) RetryTaskStep(step *TaskStep, reason string) error {
+	if step.Status != TaskStatusFailed && step.Status != TaskStatusCancelled {
+		pa.recordAdjustment(step.ID, "manual_retry", "rejected", reason, false, 0.0)
+		return fmt.Errorf("step %s is %s; only failed or cancelled steps can be retried", step.ID, step.Status)
+	}
+
+	step.Status = TaskStatusPending
+	step.StartedAt = nil
+	step.CompletedAt = nil
+	step.Output = nil
+	step.Error = nil
+
+	pa.recordAdjustment(step.ID, "manual_retry", "applied", reason, true, 0.0)
+	return nil
+}
+
 // Helper function for absolute value
 func abs(x int) int {
 	if x < 0 {
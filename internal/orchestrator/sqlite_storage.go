@@ -0,0 +1,822 @@
+package orchestrator
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStorage is a Storage implementation backed by an embedded SQLite
+// database. Several claude-company processes may share the same database file;
+// WAL mode and a busy timeout keep concurrent writers consistent.
+type SQLiteStorage struct {
+	db     *sql.DB
+	config SQLiteStorageConfig
+}
+
+// SQLiteStorageConfig configures a SQLiteStorage
+type SQLiteStorageConfig struct {
+	Path string `json:"path"`
+	// Retention is how long finished tasks, offline workers and their events
+	// are kept before Cleanup removes them.
+	Retention   time.Duration `json:"retention"`
+	BusyTimeout time.Duration `json:"busy_timeout"`
+}
+
+// sqliteMigrations are applied in order; the index+1 is the schema version.
+// Never edit an existing entry, append a new one instead.
+var sqliteMigrations = []string{
+	// 1: initial schema
+	`CREATE TABLE tasks (
+		id           TEXT PRIMARY KEY,
+		type         TEXT NOT NULL,
+		title        TEXT NOT NULL,
+		description  TEXT NOT NULL,
+		status       TEXT NOT NULL,
+		priority     TEXT NOT NULL,
+		created_at   INTEGER NOT NULL,
+		updated_at   INTEGER NOT NULL,
+		completed_at INTEGER,
+		plan_id      TEXT,
+		context      TEXT NOT NULL
+	);
+	CREATE INDEX idx_tasks_status ON tasks(status);
+	CREATE INDEX idx_tasks_type ON tasks(type);
+	CREATE INDEX idx_tasks_priority ON tasks(priority);
+	CREATE INDEX idx_tasks_created_at ON tasks(created_at);
+
+	CREATE TABLE plans (
+		id             TEXT PRIMARY KEY,
+		task_id        TEXT NOT NULL,
+		strategy       TEXT NOT NULL,
+		estimated_time INTEGER NOT NULL,
+		actual_time    INTEGER,
+		subtasks       TEXT NOT NULL,
+		dependencies   TEXT NOT NULL,
+		created_at     INTEGER NOT NULL,
+		updated_at     INTEGER NOT NULL
+	);
+	CREATE INDEX idx_plans_task_id ON plans(task_id);
+
+	CREATE TABLE steps (
+		plan_id        TEXT NOT NULL REFERENCES plans(id) ON DELETE CASCADE,
+		id             TEXT NOT NULL,
+		parent_task_id TEXT NOT NULL,
+		name           TEXT NOT NULL,
+		type           INTEGER NOT NULL,
+		description    TEXT NOT NULL,
+		step_order     INTEGER NOT NULL,
+		status         TEXT NOT NULL,
+		dependencies   TEXT NOT NULL,
+		started_at     INTEGER,
+		completed_at   INTEGER,
+		output         TEXT,
+		error          TEXT,
+		PRIMARY KEY (plan_id, id)
+	);
+	CREATE INDEX idx_steps_status ON steps(status);
+	CREATE INDEX idx_steps_parent_task_id ON steps(parent_task_id);
+
+	CREATE TABLE workers (
+		id           TEXT PRIMARY KEY,
+		name         TEXT NOT NULL,
+		type         TEXT NOT NULL,
+		status       TEXT NOT NULL,
+		capabilities TEXT NOT NULL,
+		current_task TEXT,
+		last_seen    INTEGER NOT NULL
+	);
+	CREATE INDEX idx_workers_status ON workers(status);
+
+	CREATE TABLE events (
+		seq       INTEGER PRIMARY KEY AUTOINCREMENT,
+		id        TEXT NOT NULL,
+		task_id   TEXT NOT NULL,
+		type      TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		data      TEXT NOT NULL
+	);
+	CREATE INDEX idx_events_task_id ON events(task_id, timestamp);
+	CREATE INDEX idx_events_type ON events(type, timestamp);`,
+}
+
+// NewSQLiteStorage opens (creating if needed) the database at config.Path and
+// applies any pending schema migrations.
+func NewSQLiteStorage(config SQLiteStorageConfig) (*SQLiteStorage, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("database path is required")
+	}
+	if config.Retention <= 0 {
+		config.Retention = 7 * 24 * time.Hour
+	}
+	if config.BusyTimeout <= 0 {
+		config.BusyTimeout = 5 * time.Second
+	}
+
+	if err := os.MkdirAll(filepath.Dir(config.Path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	// The path is made absolute, so that its first element is not read as a
+	// host, and escaped, so that "?", "#" or "%" in a project path neither
+	// truncate the file name nor leak into the pragmas
+	path, err := filepath.Abs(config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve database path: %w", err)
+	}
+	dsn := url.URL{
+		Scheme:   "file",
+		Path:     path,
+		RawQuery: fmt.Sprintf("_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)", config.BusyTimeout.Milliseconds()),
+	}
+	db, err := sql.Open("sqlite", dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	s := &SQLiteStorage{db: db, config: config}
+	if err := s.migrate(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close closes the underlying database
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
+
+// DB exposes the database handle for reporting queries
+func (s *SQLiteStorage) DB() *sql.DB {
+	return s.db
+}
+
+// SchemaVersion returns the currently applied migration version
+func (s *SQLiteStorage) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+func (s *SQLiteStorage) migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	for i, migration := range sqliteMigrations {
+		version := i + 1
+		err := s.withImmediateTx(ctx, func(conn *sql.Conn) error {
+			var applied int
+			if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations WHERE version = ?`, version).Scan(&applied); err != nil {
+				return err
+			}
+			if applied > 0 {
+				return nil
+			}
+			if _, err := conn.ExecContext(ctx, migration); err != nil {
+				return err
+			}
+			_, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, version, time.Now().UnixNano())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %d: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SQLiteStorage) SaveTask(ctx context.Context, task *Task) error {
+	if task == nil || task.ID == "" {
+		return fmt.Errorf("task must have an ID")
+	}
+
+	taskContext, err := json.Marshal(task.Context)
+	if err != nil {
+		return fmt.Errorf("failed to encode task context: %w", err)
+	}
+
+	var planID sql.NullString
+	if task.Plan != nil {
+		planID = sql.NullString{String: task.Plan.ID, Valid: task.Plan.ID != ""}
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO tasks
+			(id, type, title, description, status, priority, created_at, updated_at, completed_at, plan_id, context)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET
+				type = excluded.type, title = excluded.title, description = excluded.description,
+				status = excluded.status, priority = excluded.priority, updated_at = excluded.updated_at,
+				completed_at = excluded.completed_at, plan_id = excluded.plan_id, context = excluded.context`,
+			task.ID, string(task.Type), task.Title, task.Description, string(task.Status), string(task.Priority),
+			task.CreatedAt.UnixNano(), task.UpdatedAt.UnixNano(), nullTime(task.CompletedAt), planID, string(taskContext),
+		); err != nil {
+			return fmt.Errorf("failed to save task: %w", err)
+		}
+
+		if planID.Valid {
+			return s.savePlanTx(ctx, tx, task.Plan)
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStorage) LoadTask(ctx context.Context, taskID string) (*Task, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT id, type, title, description, status, priority,
+		created_at, updated_at, completed_at, plan_id, context FROM tasks WHERE id = ?`, taskID)
+
+	task, planID, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("task not found: %s", taskID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task %s: %w", taskID, err)
+	}
+
+	if planID != "" {
+		if plan, err := s.LoadPlan(ctx, planID); err == nil {
+			task.Plan = plan
+		}
+	}
+	return task, nil
+}
+
+func (s *SQLiteStorage) ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error) {
+	var where []string
+	var args []any
+
+	if len(filter.Status) > 0 {
+		where = append(where, "status IN ("+placeholders(len(filter.Status))+")")
+		for _, v := range filter.Status {
+			args = append(args, string(v))
+		}
+	}
+	if len(filter.Type) > 0 {
+		where = append(where, "type IN ("+placeholders(len(filter.Type))+")")
+		for _, v := range filter.Type {
+			args = append(args, string(v))
+		}
+	}
+	if len(filter.Priority) > 0 {
+		where = append(where, "priority IN ("+placeholders(len(filter.Priority))+")")
+		for _, v := range filter.Priority {
+			args = append(args, string(v))
+		}
+	}
+	if filter.CreatedAfter != nil {
+		if t, err := time.Parse(time.RFC3339, *filter.CreatedAfter); err == nil {
+			where = append(where, "created_at > ?")
+			args = append(args, t.UnixNano())
+		}
+	}
+	if filter.CreatedBefore != nil {
+		if t, err := time.Parse(time.RFC3339, *filter.CreatedBefore); err == nil {
+			where = append(where, "created_at < ?")
+			args = append(args, t.UnixNano())
+		}
+	}
+
+	query := `SELECT id, type, title, description, status, priority,
+		created_at, updated_at, completed_at, plan_id, context FROM tasks`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if filter.Limit > 0 || filter.Offset > 0 {
+		limit := -1
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, filter.Offset)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make([]*Task, 0)
+	planIDs := make([]string, 0)
+	for rows.Next() {
+		task, planID, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+		planIDs = append(planIDs, planID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	rows.Close()
+
+	for i, planID := range planIDs {
+		if planID == "" {
+			continue
+		}
+		if plan, err := s.LoadPlan(ctx, planID); err == nil {
+			tasks[i].Plan = plan
+		}
+	}
+
+	return tasks, nil
+}
+
+func (s *SQLiteStorage) DeleteTask(ctx context.Context, taskID string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM tasks WHERE id = ?`, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to delete task %s: %w", taskID, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("task not found: %s", taskID)
+	}
+	return nil
+}
+
+func (s *SQLiteStorage) SavePlan(ctx context.Context, plan *TaskPlan) error {
+	if plan == nil || plan.ID == "" {
+		return fmt.Errorf("plan must have an ID")
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return s.savePlanTx(ctx, tx, plan)
+	})
+}
+
+func (s *SQLiteStorage) savePlanTx(ctx context.Context, tx *sql.Tx, plan *TaskPlan) error {
+	subtasks, err := json.Marshal(nonNilSlice(plan.SubTasks))
+	if err != nil {
+		return fmt.Errorf("failed to encode subtasks: %w", err)
+	}
+	dependencies, err := json.Marshal(nonNilSlice(plan.Dependencies))
+	if err != nil {
+		return fmt.Errorf("failed to encode plan dependencies: %w", err)
+	}
+
+	var actualTime sql.NullInt64
+	if plan.ActualTime != nil {
+		actualTime = sql.NullInt64{Int64: int64(*plan.ActualTime), Valid: true}
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO plans
+		(id, task_id, strategy, estimated_time, actual_time, subtasks, dependencies, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			task_id = excluded.task_id, strategy = excluded.strategy, estimated_time = excluded.estimated_time,
+			actual_time = excluded.actual_time, subtasks = excluded.subtasks,
+			dependencies = excluded.dependencies, updated_at = excluded.updated_at`,
+		plan.ID, plan.TaskID, string(plan.Strategy), int64(plan.EstimatedTime), actualTime,
+		string(subtasks), string(dependencies), plan.CreatedAt.UnixNano(), plan.UpdatedAt.UnixNano(),
+	); err != nil {
+		return fmt.Errorf("failed to save plan: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM steps WHERE plan_id = ?`, plan.ID); err != nil {
+		return fmt.Errorf("failed to replace plan steps: %w", err)
+	}
+
+	for _, step := range plan.Steps {
+		stepDeps, err := json.Marshal(nonNilSlice(step.Dependencies))
+		if err != nil {
+			return fmt.Errorf("failed to encode step dependencies: %w", err)
+		}
+		output, err := nullJSON(step.Output)
+		if err != nil {
+			return fmt.Errorf("failed to encode step output: %w", err)
+		}
+		stepErr, err := nullJSON(step.Error)
+		if err != nil {
+			return fmt.Errorf("failed to encode step error: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO steps
+			(plan_id, id, parent_task_id, name, type, description, step_order, status, dependencies,
+			 started_at, completed_at, output, error)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			plan.ID, step.ID, step.ParentTaskID, step.Name, int(step.Type), step.Description, step.Order,
+			string(step.Status), string(stepDeps), nullTime(step.StartedAt), nullTime(step.CompletedAt), output, stepErr,
+		); err != nil {
+			return fmt.Errorf("failed to save step %s: %w", step.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SQLiteStorage) LoadPlan(ctx context.Context, planID string) (*TaskPlan, error) {
+	var plan TaskPlan
+	var strategy, subtasks, dependencies string
+	var estimated, createdAt, updatedAt int64
+	var actualTime sql.NullInt64
+
+	err := s.db.QueryRowContext(ctx, `SELECT id, task_id, strategy, estimated_time, actual_time,
+		subtasks, dependencies, created_at, updated_at FROM plans WHERE id = ?`, planID).Scan(
+		&plan.ID, &plan.TaskID, &strategy, &estimated, &actualTime, &subtasks, &dependencies, &createdAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plan %s: %w", planID, err)
+	}
+
+	plan.Strategy = PlanStrategy(strategy)
+	plan.EstimatedTime = time.Duration(estimated)
+	if actualTime.Valid {
+		d := time.Duration(actualTime.Int64)
+		plan.ActualTime = &d
+	}
+	plan.CreatedAt = time.Unix(0, createdAt)
+	plan.UpdatedAt = time.Unix(0, updatedAt)
+	if err := json.Unmarshal([]byte(subtasks), &plan.SubTasks); err != nil {
+		return nil, fmt.Errorf("failed to decode subtasks: %w", err)
+	}
+	if err := json.Unmarshal([]byte(dependencies), &plan.Dependencies); err != nil {
+		return nil, fmt.Errorf("failed to decode plan dependencies: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, parent_task_id, name, type, description, step_order,
+		status, dependencies, started_at, completed_at, output, error
+		FROM steps WHERE plan_id = ? ORDER BY step_order`, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plan steps: %w", err)
+	}
+	defer rows.Close()
+
+	plan.Steps = make([]TaskStep, 0)
+	for rows.Next() {
+		var step TaskStep
+		var stepType int
+		var status, stepDeps string
+		var startedAt, completedAt sql.NullInt64
+		var output, stepErr sql.NullString
+
+		if err := rows.Scan(&step.ID, &step.ParentTaskID, &step.Name, &stepType, &step.Description, &step.Order,
+			&status, &stepDeps, &startedAt, &completedAt, &output, &stepErr); err != nil {
+			return nil, fmt.Errorf("failed to scan step: %w", err)
+		}
+
+		step.Type = StepType(stepType)
+		step.Status = TaskStatus(status)
+		step.StartedAt = timePtr(startedAt)
+		step.CompletedAt = timePtr(completedAt)
+		if err := json.Unmarshal([]byte(stepDeps), &step.Dependencies); err != nil {
+			return nil, fmt.Errorf("failed to decode step dependencies: %w", err)
+		}
+		if output.Valid {
+			step.Output = &StepOutput{}
+			if err := json.Unmarshal([]byte(output.String), step.Output); err != nil {
+				return nil, fmt.Errorf("failed to decode step output: %w", err)
+			}
+		}
+		if stepErr.Valid {
+			step.Error = &StepError{}
+			if err := json.Unmarshal([]byte(stepErr.String), step.Error); err != nil {
+				return nil, fmt.Errorf("failed to decode step error: %w", err)
+			}
+		}
+
+		plan.Steps = append(plan.Steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load plan steps: %w", err)
+	}
+
+	return &plan, nil
+}
+
+func (s *SQLiteStorage) DeletePlan(ctx context.Context, planID string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM plans WHERE id = ?`, planID)
+	if err != nil {
+		return fmt.Errorf("failed to delete plan %s: %w", planID, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("plan not found: %s", planID)
+	}
+	return nil
+}
+
+func (s *SQLiteStorage) SaveWorker(ctx context.Context, worker *Worker) error {
+	if worker == nil || worker.ID == "" {
+		return fmt.Errorf("worker must have an ID")
+	}
+
+	capabilities, err := json.Marshal(nonNilSlice(worker.Capabilities))
+	if err != nil {
+		return fmt.Errorf("failed to encode capabilities: %w", err)
+	}
+
+	var currentTask sql.NullString
+	if worker.CurrentTask != nil {
+		currentTask = sql.NullString{String: *worker.CurrentTask, Valid: true}
+	}
+
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO workers
+		(id, name, type, status, capabilities, current_task, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name, type = excluded.type, status = excluded.status,
+			capabilities = excluded.capabilities, current_task = excluded.current_task,
+			last_seen = excluded.last_seen`,
+		worker.ID, worker.Name, worker.Type, string(worker.Status), string(capabilities), currentTask, worker.LastSeen.UnixNano(),
+	); err != nil {
+		return fmt.Errorf("failed to save worker: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStorage) LoadWorker(ctx context.Context, workerID string) (*Worker, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT id, name, type, status, capabilities, current_task, last_seen
+		FROM workers WHERE id = ?`, workerID)
+
+	worker, err := scanWorker(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("worker not found: %s", workerID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load worker %s: %w", workerID, err)
+	}
+	return worker, nil
+}
+
+func (s *SQLiteStorage) ListWorkers(ctx context.Context) ([]*Worker, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name, type, status, capabilities, current_task, last_seen
+		FROM workers ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workers: %w", err)
+	}
+	defer rows.Close()
+
+	workers := make([]*Worker, 0)
+	for rows.Next() {
+		worker, err := scanWorker(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan worker: %w", err)
+		}
+		workers = append(workers, worker)
+	}
+	return workers, rows.Err()
+}
+
+func (s *SQLiteStorage) DeleteWorker(ctx context.Context, workerID string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM workers WHERE id = ?`, workerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete worker %s: %w", workerID, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("worker not found: %s", workerID)
+	}
+	return nil
+}
+
+func (s *SQLiteStorage) SaveEvent(ctx context.Context, event *TaskEvent) error {
+	if event == nil {
+		return fmt.Errorf("event is nil")
+	}
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event data: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `INSERT INTO events (id, task_id, type, timestamp, data) VALUES (?, ?, ?, ?, ?)`,
+		event.ID, event.TaskID, string(event.Type), event.Timestamp.UnixNano(), string(data)); err != nil {
+		return fmt.Errorf("failed to save event: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStorage) ListEvents(ctx context.Context, filter EventFilter) ([]*TaskEvent, error) {
+	var where []string
+	var args []any
+
+	if len(filter.EventTypes) > 0 {
+		where = append(where, "type IN ("+placeholders(len(filter.EventTypes))+")")
+		for _, v := range filter.EventTypes {
+			args = append(args, string(v))
+		}
+	}
+	if len(filter.TaskIDs) > 0 {
+		where = append(where, "task_id IN ("+placeholders(len(filter.TaskIDs))+")")
+		for _, v := range filter.TaskIDs {
+			args = append(args, v)
+		}
+	}
+
+	query := `SELECT id, task_id, type, timestamp, data FROM events`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY seq"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*TaskEvent, 0)
+	for rows.Next() {
+		var event TaskEvent
+		var eventType, data string
+		var timestamp int64
+		if err := rows.Scan(&event.ID, &event.TaskID, &eventType, &timestamp, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		event.Type = TaskEventType(eventType)
+		event.Timestamp = time.Unix(0, timestamp)
+		if err := json.Unmarshal([]byte(data), &event.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode event data: %w", err)
+		}
+
+		// Conditions match against free-form data and are checked in Go
+		if len(filter.Conditions) > 0 && !MatchEventFilter(event, EventFilter{Conditions: filter.Conditions}) {
+			continue
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+// Cleanup removes finished tasks (with their plans and events) and offline
+// workers whose last update is older than the configured retention.
+func (s *SQLiteStorage) Cleanup(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.config.Retention).UnixNano()
+	terminal := []any{string(TaskStatusCompleted), string(TaskStatusFailed), string(TaskStatusCancelled)}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		args := append([]any{cutoff}, terminal...)
+		expired := `SELECT id FROM tasks WHERE updated_at < ? AND status IN (` + placeholders(len(terminal)) + `)`
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM events WHERE task_id IN (`+expired+`)`, args...); err != nil {
+			return fmt.Errorf("failed to clean up events: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM plans WHERE task_id IN (`+expired+`)`, args...); err != nil {
+			return fmt.Errorf("failed to clean up plans: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM tasks WHERE id IN (`+expired+`)`, args...); err != nil {
+			return fmt.Errorf("failed to clean up tasks: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM events WHERE task_id = '' AND timestamp < ?`, cutoff); err != nil {
+			return fmt.Errorf("failed to clean up events: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM workers WHERE status = ? AND last_seen < ?`,
+			string(WorkerStatusOffline), cutoff); err != nil {
+			return fmt.Errorf("failed to clean up workers: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStorage) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// withImmediateTx runs fn in a transaction that takes the write lock up front
+// (BEGIN IMMEDIATE), so that processes opening a fresh database together apply
+// each migration once instead of racing from the same check
+func (s *SQLiteStorage) withImmediateTx(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `BEGIN IMMEDIATE`); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(conn); err != nil {
+		conn.ExecContext(context.WithoutCancel(ctx), `ROLLBACK`)
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, `COMMIT`); err != nil {
+		conn.ExecContext(context.WithoutCancel(ctx), `ROLLBACK`)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(row rowScanner) (*Task, string, error) {
+	var task Task
+	var taskType, status, priority, taskContext string
+	var createdAt, updatedAt int64
+	var completedAt sql.NullInt64
+	var planID sql.NullString
+
+	if err := row.Scan(&task.ID, &taskType, &task.Title, &task.Description, &status, &priority,
+		&createdAt, &updatedAt, &completedAt, &planID, &taskContext); err != nil {
+		return nil, "", err
+	}
+
+	task.Type = TaskType(taskType)
+	task.Status = TaskStatus(status)
+	task.Priority = TaskPriority(priority)
+	task.CreatedAt = time.Unix(0, createdAt)
+	task.UpdatedAt = time.Unix(0, updatedAt)
+	task.CompletedAt = timePtr(completedAt)
+	if err := json.Unmarshal([]byte(taskContext), &task.Context); err != nil {
+		return nil, "", fmt.Errorf("failed to decode task context: %w", err)
+	}
+
+	return &task, planID.String, nil
+}
+
+func scanWorker(row rowScanner) (*Worker, error) {
+	var worker Worker
+	var status, capabilities string
+	var currentTask sql.NullString
+	var lastSeen int64
+
+	if err := row.Scan(&worker.ID, &worker.Name, &worker.Type, &status, &capabilities, &currentTask, &lastSeen); err != nil {
+		return nil, err
+	}
+
+	worker.Status = WorkerStatus(status)
+	worker.LastSeen = time.Unix(0, lastSeen)
+	if currentTask.Valid {
+		worker.CurrentTask = &currentTask.String
+	}
+	if err := json.Unmarshal([]byte(capabilities), &worker.Capabilities); err != nil {
+		return nil, fmt.Errorf("failed to decode capabilities: %w", err)
+	}
+	return &worker, nil
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func nullTime(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.UnixNano(), Valid: true}
+}
+
+func timePtr(v sql.NullInt64) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := time.Unix(0, v.Int64)
+	return &t
+}
+
+func nullJSON(v any) (sql.NullString, error) {
+	switch val := v.(type) {
+	case *StepOutput:
+		if val == nil {
+			return sql.NullString{}, nil
+		}
+	case *StepError:
+		if val == nil {
+			return sql.NullString{}, nil
+		}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func nonNilSlice[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
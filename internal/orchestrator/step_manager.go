@@ -2,6 +2,7 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
@@ -17,6 +18,7 @@ type StepManager struct {
 	storage          Storage
 	config           StepManagerConfig
 	executorPool     *ExecutorPool
+	stepSaver        func(ctx context.Context, step *TaskStep) error
 }
 
 type StepManagerConfig struct {
@@ -36,8 +38,26 @@ type StepExecution struct {
 	Output     *StepOutput       `json:"output,omitempty"`
 	Error      error             `json:"-"`
 	RetryCount int               `json:"retry_count"`
+
+	// cancelAttempt aborts only the running attempt so that it is retried
+	cancelAttempt context.CancelCauseFunc
+	// cancelled is set by CancelStep so that the step is not marked failed
+	cancelled bool
 }
 
+// ErrWorkerLost is the cause of a step attempt aborted by RequeueStep
+// because the worker running it died or hung
+var ErrWorkerLost = errors.New("worker lost")
+
+// ErrShutdown is the cause of contexts cancelled because the process is
+// shutting down. Steps interrupted by it stay in progress so that the plan
+// can be resumed, instead of being marked failed.
+var ErrShutdown = errors.New("shutting down")
+
+// ErrRetryRequested is the cause of a step attempt aborted by RestartStep.
+// Unlike a lost worker it does not use up one of the step's retries.
+var ErrRetryRequested = errors.New("retry requested")
+
 type ExecutorPool struct {
 	workers   chan struct{}
 	executing sync.Map
@@ -84,9 +104,17 @@ func (sm *StepManager) CreateStep(ctx context.Context, step *TaskStep) error {
 		step.Status = TaskStatusPending
 	}
 
+	// A plan executed again registers its steps again
+	previous, registered := sm.steps[step.ID]
 	sm.steps[step.ID] = step
 
-	if step.ParentTaskID != "" {
+	if step.ParentTaskID != "" && registered {
+		for i, existing := range sm.stepsByTask[step.ParentTaskID] {
+			if existing == previous {
+				sm.stepsByTask[step.ParentTaskID][i] = step
+			}
+		}
+	} else if step.ParentTaskID != "" {
 		sm.stepsByTask[step.ParentTaskID] = append(sm.stepsByTask[step.ParentTaskID], step)
 		sort.Slice(sm.stepsByTask[step.ParentTaskID], func(i, j int) bool {
 			return sm.stepsByTask[step.ParentTaskID][i].Order < sm.stepsByTask[step.ParentTaskID][j].Order
@@ -192,14 +220,16 @@ func (sm *StepManager) ExecuteStep(ctx context.Context, stepID string, executor
 		return fmt.Errorf("step %s is not in pending status: %s", stepID, step.Status)
 	}
 
+	// A full pool delays the step until an earlier one finishes, like a
+	// worker pool waiting for an idle worker, instead of failing the plan
 	select {
 	case sm.executorPool.workers <- struct{}{}:
-		sm.executorPool.wg.Add(1)
-		go sm.executeStepAsync(ctx, step, executor)
-		return nil
-	default:
-		return fmt.Errorf("executor pool is full")
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+	sm.executorPool.wg.Add(1)
+	go sm.executeStepAsync(ctx, step, executor)
+	return nil
 }
 
 func (sm *StepManager) executeStepAsync(ctx context.Context, step *TaskStep, executor StepExecutorFunc) {
@@ -235,13 +265,37 @@ func (sm *StepManager) executeStepAsync(ctx context.Context, step *TaskStep, exe
 
 	output, err := sm.executeWithRetry(stepCtx, step, executor, execution)
 
+	sm.mu.RLock()
+	cancelled := execution.cancelled
+	sm.mu.RUnlock()
+	if cancelled {
+		return
+	}
+
+	// A step interrupted by shutdown is left in progress; its worker may
+	// still finish it and report before the plan is resumed
+	if errors.Is(context.Cause(ctx), ErrShutdown) {
+		sm.mu.Lock()
+		if sm.storage != nil {
+			sm.saveStepToStorage(context.WithoutCancel(ctx), step)
+		}
+		sm.mu.Unlock()
+		return
+	}
+
 	if err != nil {
+		stepErr := &StepError{
+			Code:    "execution_failed",
+			Message: err.Error(),
+		}
+		var failed *StepFailedError
+		if errors.As(err, &failed) && failed.StepError != nil {
+			stepErr = failed.StepError
+		}
+
 		sm.UpdateStep(stepCtx, step.ID, StepUpdate{
 			Status: &[]TaskStatus{TaskStatusFailed}[0],
-			Error: &StepError{
-				Code:    "execution_failed",
-				Message: err.Error(),
-			},
+			Error:  stepErr,
 		})
 		return
 	}
@@ -255,9 +309,11 @@ func (sm *StepManager) executeStepAsync(ctx context.Context, step *TaskStep, exe
 func (sm *StepManager) executeWithRetry(ctx context.Context, step *TaskStep, executor StepExecutorFunc, execution *StepExecution) (*StepOutput, error) {
 	var lastErr error
 
-	for attempt := 0; attempt <= sm.config.RetryPolicy.MaxRetries; attempt++ {
+	// restarts counts attempts aborted by RestartStep, which are not retries
+	restarts := 0
+	for attempt := 0; attempt <= sm.config.RetryPolicy.MaxRetries+restarts; attempt++ {
 		if attempt > 0 {
-			backoff := sm.calculateBackoff(attempt)
+			backoff := sm.calculateBackoff(max(attempt-restarts, 1))
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
@@ -276,16 +332,33 @@ func (sm *StepManager) executeWithRetry(ctx context.Context, step *TaskStep, exe
 						"attempt": attempt,
 					},
 				}
+				if errors.Is(lastErr, ErrWorkerLost) || errors.Is(lastErr, ErrRetryRequested) {
+					event.Data["reason"] = lastErr.Error()
+				}
 				sm.eventBus.Publish(ctx, event)
 			}
 		}
 
-		output, err := executor(ctx, step)
+		attemptCtx, cancelAttempt := context.WithCancelCause(ctx)
+		sm.mu.Lock()
+		execution.cancelAttempt = cancelAttempt
+		sm.mu.Unlock()
+
+		output, err := executor(attemptCtx, step)
+		cause := context.Cause(attemptCtx)
+		requeued := ctx.Err() == nil && (errors.Is(cause, ErrWorkerLost) || errors.Is(cause, ErrRetryRequested))
+		cancelAttempt(nil)
 		if err == nil {
 			return output, nil
 		}
 
 		lastErr = err
+		if requeued {
+			lastErr = cause
+			if errors.Is(cause, ErrRetryRequested) {
+				restarts++
+			}
+		}
 
 		if !sm.isRetryableError(err) {
 			break
@@ -382,20 +455,127 @@ func (sm *StepManager) estimateRemainingTime(execution *StepExecution) *time.Dur
 	return &remaining
 }
 
+// CancelStep stops a pending or running step and marks it cancelled
 func (sm *StepManager) CancelStep(ctx context.Context, stepID string) error {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
+	step, exists := sm.steps[stepID]
+	if !exists {
+		sm.mu.Unlock()
+		return fmt.Errorf("step not found: %s", stepID)
+	}
+	if step.Status != TaskStatusPending && step.Status != TaskStatusInProgress {
+		sm.mu.Unlock()
+		return fmt.Errorf("step %s is already %s", stepID, step.Status)
+	}
 
-	execution, exists := sm.stepExecutions[stepID]
-	if exists {
+	if execution, running := sm.stepExecutions[stepID]; running {
+		execution.cancelled = true
 		execution.Cancel()
 	}
+	// UpdateStep takes the lock itself
+	sm.mu.Unlock()
 
 	return sm.UpdateStep(ctx, stepID, StepUpdate{
 		Status: &[]TaskStatus{TaskStatusCancelled}[0],
 	})
 }
 
+// RequeueStep aborts the running attempt of a step, e.g. because its worker
+// was lost, so that it is retried with the step's retry policy
+func (sm *StepManager) RequeueStep(ctx context.Context, stepID string, reason string) error {
+	return sm.abortAttempt(stepID, fmt.Errorf("%w: %s", ErrWorkerLost, reason))
+}
+
+// RestartStep aborts the running attempt of a step and starts it again
+// without using up one of its retries
+func (sm *StepManager) RestartStep(ctx context.Context, stepID string, reason string) error {
+	return sm.abortAttempt(stepID, fmt.Errorf("%w: %s", ErrRetryRequested, reason))
+}
+
+func (sm *StepManager) abortAttempt(stepID string, cause error) error {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	execution, exists := sm.stepExecutions[stepID]
+	if !exists || execution.cancelAttempt == nil {
+		return fmt.Errorf("step %s is not running", stepID)
+	}
+
+	execution.cancelAttempt(cause)
+	return nil
+}
+
+// ResetStep returns a finished step to pending so that it can be executed
+// again. reset applies the change and is called with the manager's lock held.
+func (sm *StepManager) ResetStep(ctx context.Context, stepID string, reset func(step *TaskStep) error) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	step, exists := sm.steps[stepID]
+	if !exists {
+		return fmt.Errorf("step not found: %s", stepID)
+	}
+	if _, running := sm.stepExecutions[stepID]; running {
+		return fmt.Errorf("step %s is running", stepID)
+	}
+	if err := reset(step); err != nil {
+		return err
+	}
+
+	if sm.storage != nil {
+		if err := sm.saveStepToStorage(ctx, step); err != nil {
+			return fmt.Errorf("failed to update step in storage: %w", err)
+		}
+	}
+
+	if sm.eventBus != nil {
+		event := TaskEvent{
+			ID:        generateEventID(),
+			TaskID:    step.ParentTaskID,
+			Type:      TaskEventRetried,
+			Timestamp: time.Now(),
+			Data: map[string]any{
+				"step_id": step.ID,
+				"status":  step.Status,
+			},
+		}
+		sm.eventBus.Publish(ctx, event)
+	}
+
+	return nil
+}
+
+// SnapshotStep returns a copy of a registered step, safe to read while the
+// step is executing
+func (sm *StepManager) SnapshotStep(stepID string) (TaskStep, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	step, exists := sm.steps[stepID]
+	if !exists {
+		return TaskStep{}, false
+	}
+	return *step, true
+}
+
+// SnapshotSteps copies steps under the step manager's lock, taking the
+// current state of registered steps. Registered steps may be the very
+// elements of steps, which the manager updates concurrently.
+func (sm *StepManager) SnapshotSteps(steps []TaskStep) []TaskStep {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	snapshot := make([]TaskStep, len(steps))
+	for i := range steps {
+		if step, exists := sm.steps[steps[i].ID]; exists {
+			snapshot[i] = *step
+		} else {
+			snapshot[i] = steps[i]
+		}
+	}
+	return snapshot
+}
+
 func (sm *StepManager) WaitForCompletion(ctx context.Context, stepIDs []string) error {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
@@ -423,13 +603,15 @@ func (sm *StepManager) WaitForCompletion(ctx context.Context, stepIDs []string)
 	}
 }
 
+// Shutdown cancels the running steps and waits for their goroutines to end.
+// The goroutines update their step on the way out, so sm.mu is only held
+// while cancelling.
 func (sm *StepManager) Shutdown(ctx context.Context) error {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
-
 	for _, execution := range sm.stepExecutions {
 		execution.Cancel()
 	}
+	sm.mu.Unlock()
 
 	done := make(chan struct{})
 	go func() {
@@ -445,12 +627,37 @@ func (sm *StepManager) Shutdown(ctx context.Context) error {
 	}
 }
 
+// SetStepSaver sets how step state is persisted. Steps are stored as part
+// of their plan, so the plan manager saves the plan owning the step.
+func (sm *StepManager) SetStepSaver(saver func(ctx context.Context, step *TaskStep) error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.stepSaver = saver
+}
+
+// saveStepToStorage must be called with sm.mu held
 func (sm *StepManager) saveStepToStorage(ctx context.Context, step *TaskStep) error {
-	return nil
+	if sm.stepSaver == nil {
+		return nil
+	}
+	return sm.stepSaver(ctx, step)
 }
 
 type StepExecutorFunc func(ctx context.Context, step *TaskStep) (*StepOutput, error)
 
+// StepFailedError lets a StepExecutorFunc report a structured StepError,
+// which is recorded on the step instead of a generic execution_failed error
+type StepFailedError struct {
+	StepError *StepError
+}
+
+func (e *StepFailedError) Error() string {
+	if e.StepError == nil {
+		return "step failed"
+	}
+	return fmt.Sprintf("%s: %s", e.StepError.Code, e.StepError.Message)
+}
+
 type StepUpdate struct {
 	Status *TaskStatus  `json:"status,omitempty"`
 	Output *StepOutput  `json:"output,omitempty"`
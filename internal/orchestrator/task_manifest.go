@@ -0,0 +1,230 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Metadata keys set on tasks created from a manifest
+const (
+	ManifestIDMetadataKey        = "manifest_id"
+	ManifestDependsOnMetadataKey = "depends_on"
+)
+
+// TaskManifest lists several tasks and the dependencies between them, e.g.
+//
+//	tasks:
+//	  - id: api
+//	    title: Add the login API
+//	    priority: high
+//	  - id: ui
+//	    file: specs/login-form.md
+//	    depends_on: [api]
+type TaskManifest struct {
+	Tasks []ManifestTask `yaml:"tasks"`
+}
+
+// ManifestTask is one task of a manifest. Its description is either given
+// inline or read from a markdown spec file (see ParseTaskSpec); fields set
+// in the manifest take precedence over the spec's front-matter.
+type ManifestTask struct {
+	ID          string         `yaml:"id"`
+	Title       string         `yaml:"title"`
+	Description string         `yaml:"description"`
+	File        string         `yaml:"file"` // relative to the manifest
+	Type        TaskType       `yaml:"type"`
+	Priority    TaskPriority   `yaml:"priority"`
+	ProjectPath string         `yaml:"project_path"`
+	Branch      string         `yaml:"branch"`
+	Metadata    map[string]any `yaml:"metadata"`
+	DependsOn   []string       `yaml:"depends_on"`
+}
+
+// LoadTaskManifest reads and validates a manifest file
+func LoadTaskManifest(path string) (*TaskManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return ParseTaskManifest(data, filepath.Dir(path))
+}
+
+// ParseTaskManifest parses and validates a manifest. Spec files are read
+// relative to baseDir.
+func ParseTaskManifest(data []byte, baseDir string) (*TaskManifest, error) {
+	var manifest TaskManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	for i := range manifest.Tasks {
+		task := &manifest.Tasks[i]
+		if task.File == "" {
+			continue
+		}
+		path := task.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		spec, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("task %s: failed to read spec: %w", task.ID, err)
+		}
+		if err := task.mergeSpec(spec); err != nil {
+			return nil, fmt.Errorf("task %s: %s: %w", task.ID, task.File, err)
+		}
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// mergeSpec fills the fields not set in the manifest from a task spec
+func (t *ManifestTask) mergeSpec(data []byte) error {
+	spec, err := ParseTaskSpec(data)
+	if err != nil {
+		return err
+	}
+	if t.Description != "" {
+		return fmt.Errorf("description and file cannot be used together")
+	}
+	t.Description = spec.Description
+	if t.Title == "" {
+		t.Title = spec.Title
+	}
+	if t.Type == "" {
+		t.Type = spec.Type
+	}
+	if t.Priority == "" {
+		t.Priority = spec.Priority
+	}
+	if t.ProjectPath == "" {
+		t.ProjectPath = spec.ProjectPath
+	}
+	if t.Branch == "" {
+		t.Branch = spec.Branch
+	}
+	for key, value := range spec.Metadata {
+		if _, exists := t.Metadata[key]; !exists {
+			if t.Metadata == nil {
+				t.Metadata = make(map[string]any)
+			}
+			t.Metadata[key] = value
+		}
+	}
+	return nil
+}
+
+// Validate checks task IDs, field values and that the dependency graph
+// refers to known tasks and has no cycles
+func (m *TaskManifest) Validate() error {
+	if len(m.Tasks) == 0 {
+		return fmt.Errorf("manifest has no tasks")
+	}
+
+	ids := make(map[string]bool, len(m.Tasks))
+	for _, task := range m.Tasks {
+		if task.ID == "" {
+			return fmt.Errorf("all tasks must have an id")
+		}
+		if ids[task.ID] {
+			return fmt.Errorf("duplicate task id: %s", task.ID)
+		}
+		ids[task.ID] = true
+
+		if strings.TrimSpace(task.Title) == "" && strings.TrimSpace(task.Description) == "" {
+			return fmt.Errorf("task %s needs a title, a description or a file", task.ID)
+		}
+		if task.Type != "" && !task.Type.IsValid() {
+			return fmt.Errorf("task %s: unknown task type %q", task.ID, task.Type)
+		}
+		if task.Priority != "" && !task.Priority.IsValid() {
+			return fmt.Errorf("task %s: unknown priority %q", task.ID, task.Priority)
+		}
+	}
+
+	graph := make(map[string][]string, len(m.Tasks))
+	nodes := make([]string, 0, len(m.Tasks))
+	for _, task := range m.Tasks {
+		for _, dep := range task.DependsOn {
+			if !ids[dep] {
+				return fmt.Errorf("task %s depends on non-existent task %s", task.ID, dep)
+			}
+		}
+		graph[task.ID] = task.DependsOn
+		nodes = append(nodes, task.ID)
+	}
+	if hasCycle(nodes, graph) {
+		return fmt.Errorf("manifest has cyclic dependencies")
+	}
+
+	return nil
+}
+
+// Order returns the tasks in dependency order. Tasks whose dependencies are
+// satisfied run first in the order they appear in the manifest.
+func (m *TaskManifest) Order() []ManifestTask {
+	done := make(map[string]bool, len(m.Tasks))
+	ordered := make([]ManifestTask, 0, len(m.Tasks))
+
+	for len(ordered) < len(m.Tasks) {
+		progressed := false
+		for _, task := range m.Tasks {
+			if done[task.ID] || !allDone(task.DependsOn, done) {
+				continue
+			}
+			done[task.ID] = true
+			ordered = append(ordered, task)
+			progressed = true
+		}
+		if !progressed {
+			break // cyclic; rejected by Validate
+		}
+	}
+	return ordered
+}
+
+func allDone(ids []string, done map[string]bool) bool {
+	for _, id := range ids {
+		if !done[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// Request converts the manifest task into a task request
+func (t ManifestTask) Request() TaskRequest {
+	metadata := make(map[string]any, len(t.Metadata)+2)
+	for key, value := range t.Metadata {
+		metadata[key] = value
+	}
+	metadata[ManifestIDMetadataKey] = t.ID
+	if len(t.DependsOn) > 0 {
+		metadata[ManifestDependsOnMetadataKey] = t.DependsOn
+	}
+
+	title := t.Title
+	if title == "" {
+		title = markdownTitle(t.Description)
+	}
+	if title == "" {
+		title = strings.TrimSpace(strings.SplitN(strings.TrimSpace(t.Description), "\n", 2)[0])
+	}
+
+	return TaskRequest{
+		Type:        t.Type,
+		Title:       title,
+		Description: t.Description,
+		Priority:    t.Priority,
+		ProjectPath: t.ProjectPath,
+		Branch:      t.Branch,
+		Metadata:    metadata,
+	}
+}
@@ -15,6 +15,9 @@ type TaskPlanManager struct {
 	eventBus EventBus
 	storage  Storage
 	stepManager *StepManager
+	stepExecutor StepExecutorFunc
+	adjuster   *PlanAdjuster
+	executions map[string]*PlanExecution
 }
 
 type PlanExecution struct {
@@ -39,13 +42,41 @@ type PlanProgress struct {
 }
 
 func NewTaskPlanManager(eventBus EventBus, storage Storage, stepManager *StepManager) *TaskPlanManager {
-	return &TaskPlanManager{
+	tpm := &TaskPlanManager{
 		plans:       make(map[string]*TaskPlan),
 		plansByTask: make(map[string]*TaskPlan),
 		eventBus:    eventBus,
 		storage:     storage,
 		stepManager: stepManager,
+		adjuster:    NewPlanAdjuster(StrategyConservative),
+		executions:  make(map[string]*PlanExecution),
 	}
+	if stepManager != nil && storage != nil {
+		stepManager.SetStepSaver(tpm.saveStepPlan)
+	}
+	return tpm
+}
+
+// saveStepPlan persists the plan owning step so that step progress is
+// visible to other processes while the plan runs
+func (tpm *TaskPlanManager) saveStepPlan(ctx context.Context, step *TaskStep) error {
+	tpm.mu.RLock()
+	plan, exists := tpm.plansByTask[step.ParentTaskID]
+	tpm.mu.RUnlock()
+	if !exists {
+		return nil
+	}
+
+	plan.UpdatedAt = time.Now()
+	return tpm.storage.SavePlan(ctx, plan)
+}
+
+// SetStepExecutor sets the function used to run each plan step. Without one,
+// steps are simulated and complete immediately.
+func (tpm *TaskPlanManager) SetStepExecutor(executor StepExecutorFunc) {
+	tpm.mu.Lock()
+	defer tpm.mu.Unlock()
+	tpm.stepExecutor = executor
 }
 
 func (tpm *TaskPlanManager) CreatePlan(ctx context.Context, plan *TaskPlan) error {
@@ -92,22 +123,27 @@ func (tpm *TaskPlanManager) CreatePlan(ctx context.Context, plan *TaskPlan) erro
 
 func (tpm *TaskPlanManager) GetPlan(ctx context.Context, planID string) (*TaskPlan, error) {
 	tpm.mu.RLock()
-	defer tpm.mu.RUnlock()
-
 	plan, exists := tpm.plans[planID]
-	if !exists {
-		if tpm.storage != nil {
-			loadedPlan, err := tpm.storage.LoadPlan(ctx, planID)
-			if err != nil {
-				return nil, fmt.Errorf("plan not found: %s", planID)
-			}
-			tpm.plans[planID] = loadedPlan
-			return loadedPlan, nil
-		}
-		return nil, fmt.Errorf("plan not found: %s", planID)
+	tpm.mu.RUnlock()
+	if exists {
+		return plan, nil
 	}
 
-	return plan, nil
+	if tpm.storage != nil {
+		loadedPlan, err := tpm.storage.LoadPlan(ctx, planID)
+		if err != nil {
+			return nil, fmt.Errorf("plan not found: %s", planID)
+		}
+		tpm.mu.Lock()
+		defer tpm.mu.Unlock()
+		if plan, exists := tpm.plans[planID]; exists {
+			return plan, nil
+		}
+		tpm.plans[planID] = loadedPlan
+		tpm.plansByTask[loadedPlan.TaskID] = loadedPlan
+		return loadedPlan, nil
+	}
+	return nil, fmt.Errorf("plan not found: %s", planID)
 }
 
 func (tpm *TaskPlanManager) GetPlanByTask(ctx context.Context, taskID string) (*TaskPlan, error) {
@@ -200,6 +236,20 @@ func (tpm *TaskPlanManager) ExecutePlan(ctx context.Context, planID string) erro
 		Status:    TaskStatusInProgress,
 	}
 
+	tpm.mu.Lock()
+	if _, running := tpm.executions[planID]; running {
+		tpm.mu.Unlock()
+		return fmt.Errorf("plan %s is already executing", planID)
+	}
+	tpm.executions[planID] = execution
+	tpm.mu.Unlock()
+
+	defer func() {
+		tpm.mu.Lock()
+		delete(tpm.executions, planID)
+		tpm.mu.Unlock()
+	}()
+
 	if tpm.eventBus != nil {
 		event := TaskEvent{
 			ID:        generateEventID(),
@@ -234,8 +284,9 @@ func (tpm *TaskPlanManager) ExecutePlan(ctx context.Context, planID string) erro
 
 	plan.ActualTime = &[]time.Duration{time.Since(execution.StartTime)}[0]
 
+	// Saved even when ctx was cancelled so that step states survive shutdown
 	if tpm.storage != nil {
-		tpm.storage.SavePlan(ctx, plan)
+		tpm.storage.SavePlan(context.WithoutCancel(ctx), plan)
 	}
 
 	if tpm.eventBus != nil {
@@ -262,12 +313,18 @@ func (tpm *TaskPlanManager) ExecutePlan(ctx context.Context, planID string) erro
 }
 
 func (tpm *TaskPlanManager) executeSequential(ctx context.Context, plan *TaskPlan) error {
-	for _, step := range plan.Steps {
-		if err := tpm.stepManager.CreateStep(ctx, &step); err != nil {
+	for i := range plan.Steps {
+		step := &plan.Steps[i]
+		if done, err := tpm.stepDone(step); err != nil {
+			return err
+		} else if done {
+			continue
+		}
+		if err := tpm.stepManager.CreateStep(ctx, step); err != nil {
 			return fmt.Errorf("failed to create step %s: %w", step.ID, err)
 		}
 
-		executor := tpm.createStepExecutor(step)
+		executor := tpm.createStepExecutor(*step)
 		if err := tpm.stepManager.ExecuteStep(ctx, step.ID, executor); err != nil {
 			return fmt.Errorf("failed to execute step %s: %w", step.ID, err)
 		}
@@ -290,17 +347,25 @@ func (tpm *TaskPlanManager) executeSequential(ctx context.Context, plan *TaskPla
 }
 
 func (tpm *TaskPlanManager) executeParallel(ctx context.Context, plan *TaskPlan) error {
-	stepIDs := make([]string, len(plan.Steps))
-
-	for i, step := range plan.Steps {
-		if err := tpm.stepManager.CreateStep(ctx, &step); err != nil {
+	var steps []*TaskStep
+	var stepIDs []string
+
+	for i := range plan.Steps {
+		step := &plan.Steps[i]
+		if done, err := tpm.stepDone(step); err != nil {
+			return err
+		} else if done {
+			continue
+		}
+		if err := tpm.stepManager.CreateStep(ctx, step); err != nil {
 			return fmt.Errorf("failed to create step %s: %w", step.ID, err)
 		}
-		stepIDs[i] = step.ID
+		steps = append(steps, step)
+		stepIDs = append(stepIDs, step.ID)
 	}
 
-	for _, step := range plan.Steps {
-		executor := tpm.createStepExecutor(step)
+	for _, step := range steps {
+		executor := tpm.createStepExecutor(*step)
 		if err := tpm.stepManager.ExecuteStep(ctx, step.ID, executor); err != nil {
 			return fmt.Errorf("failed to execute step %s: %w", step.ID, err)
 		}
@@ -336,16 +401,24 @@ func (tpm *TaskPlanManager) executeHybrid(ctx context.Context, plan *TaskPlan) e
 			return fmt.Errorf("no steps ready for execution - possible circular dependency")
 		}
 
-		stepIDs := make([]string, len(readySteps))
-		for i, step := range readySteps {
+		var batch []*TaskStep
+		var stepIDs []string
+		for _, step := range readySteps {
+			if done, err := tpm.stepDone(step); err != nil {
+				return err
+			} else if done {
+				executed[step.ID] = true
+				continue
+			}
 			if err := tpm.stepManager.CreateStep(ctx, step); err != nil {
 				return fmt.Errorf("failed to create step %s: %w", step.ID, err)
 			}
-			stepIDs[i] = step.ID
+			batch = append(batch, step)
+			stepIDs = append(stepIDs, step.ID)
 			executing[step.ID] = true
 		}
 
-		for _, step := range readySteps {
+		for _, step := range batch {
 			executor := tpm.createStepExecutor(*step)
 			if err := tpm.stepManager.ExecuteStep(ctx, step.ID, executor); err != nil {
 				return fmt.Errorf("failed to execute step %s: %w", step.ID, err)
@@ -374,7 +447,174 @@ func (tpm *TaskPlanManager) executeHybrid(ctx context.Context, plan *TaskPlan) e
 	return nil
 }
 
+// stepDone reports whether a step needs no execution because it completed in
+// an earlier run of the plan or was cancelled. A step that failed before
+// stops the plan until it is retried.
+func (tpm *TaskPlanManager) stepDone(step *TaskStep) (bool, error) {
+	status := step.Status
+	if current, registered := tpm.stepManager.SnapshotStep(step.ID); registered {
+		status = current.Status
+	}
+
+	switch status {
+	case TaskStatusCompleted, TaskStatusCancelled:
+		return true, nil
+	case TaskStatusFailed:
+		return false, fmt.Errorf("step %s failed", step.ID)
+	default:
+		return false, nil
+	}
+}
+
+// IsExecuting reports whether the plan is being executed
+func (tpm *TaskPlanManager) IsExecuting(planID string) bool {
+	tpm.mu.RLock()
+	defer tpm.mu.RUnlock()
+	_, running := tpm.executions[planID]
+	return running
+}
+
+// PlanSteps returns a copy of the plan's steps with their current state
+func (tpm *TaskPlanManager) PlanSteps(ctx context.Context, planID string) ([]TaskStep, error) {
+	plan, err := tpm.GetPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	// UpdatePlan replaces the slice under tpm.mu, while the step manager
+	// updates the steps themselves under its own lock
+	tpm.mu.RLock()
+	steps := plan.Steps
+	tpm.mu.RUnlock()
+
+	if tpm.stepManager == nil {
+		return append([]TaskStep(nil), steps...), nil
+	}
+	return tpm.stepManager.SnapshotSteps(steps), nil
+}
+
+// CancelStep cancels a step of the plan. A step the plan has not reached yet
+// is skipped when the plan gets to it; its dependents still run.
+func (tpm *TaskPlanManager) CancelStep(ctx context.Context, planID, stepID string) error {
+	step, err := tpm.planStep(ctx, planID, stepID)
+	if err != nil {
+		return err
+	}
+
+	if _, registered := tpm.stepManager.SnapshotStep(stepID); !registered {
+		if err := tpm.stepManager.CreateStep(ctx, step); err != nil {
+			return fmt.Errorf("failed to create step %s: %w", stepID, err)
+		}
+	}
+	return tpm.stepManager.CancelStep(ctx, stepID)
+}
+
+// RetryStep runs a step of the plan again. A running step is restarted; a
+// failed or cancelled step is reset by the plan adjuster and executed again
+// right away while the plan runs, or else when the plan is executed again.
+func (tpm *TaskPlanManager) RetryStep(ctx context.Context, planID, stepID string) error {
+	step, err := tpm.planStep(ctx, planID, stepID)
+	if err != nil {
+		return err
+	}
+
+	current, registered := tpm.stepManager.SnapshotStep(stepID)
+	if !registered {
+		return fmt.Errorf("step %s has not run yet", stepID)
+	}
+	if current.Status == TaskStatusInProgress {
+		return tpm.stepManager.RestartStep(ctx, stepID, "retry requested")
+	}
+
+	err = tpm.stepManager.ResetStep(ctx, stepID, func(step *TaskStep) error {
+		return tpm.adjuster.RetryTaskStep(step, "retry requested")
+	})
+	if err != nil {
+		return err
+	}
+
+	tpm.mu.RLock()
+	execution, running := tpm.executions[planID]
+	tpm.mu.RUnlock()
+	if !running {
+		return nil
+	}
+	return tpm.stepManager.ExecuteStep(execution.Context, stepID, tpm.createStepExecutor(*step))
+}
+
+// ResumePlan prepares a plan that was interrupted, e.g. because the process
+// running it died, to be executed again. The plan and its step statuses are
+// reloaded from storage; steps that were running or failed are reset to
+// pending while completed and cancelled steps are kept and skipped by the
+// next execution. It returns the IDs of the steps that were running.
+func (tpm *TaskPlanManager) ResumePlan(ctx context.Context, planID string) ([]string, error) {
+	if tpm.IsExecuting(planID) {
+		return nil, fmt.Errorf("plan %s is already executing", planID)
+	}
+	if tpm.storage == nil {
+		return nil, fmt.Errorf("no storage to resume plan %s from", planID)
+	}
+
+	plan, err := tpm.storage.LoadPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	var interrupted []string
+	for i := range plan.Steps {
+		step := &plan.Steps[i]
+		switch step.Status {
+		case TaskStatusInProgress:
+			interrupted = append(interrupted, step.ID)
+		case TaskStatusFailed:
+		default:
+			continue
+		}
+		step.Status = TaskStatusPending
+		step.StartedAt = nil
+		step.CompletedAt = nil
+		step.Output = nil
+		step.Error = nil
+	}
+	plan.UpdatedAt = time.Now()
+
+	tpm.mu.Lock()
+	tpm.plans[plan.ID] = plan
+	tpm.plansByTask[plan.TaskID] = plan
+	tpm.mu.Unlock()
+
+	if err := tpm.storage.SavePlan(ctx, plan); err != nil {
+		return nil, fmt.Errorf("failed to save plan: %w", err)
+	}
+	return interrupted, nil
+}
+
+func (tpm *TaskPlanManager) planStep(ctx context.Context, planID, stepID string) (*TaskStep, error) {
+	if tpm.stepManager == nil {
+		return nil, fmt.Errorf("no step manager to control steps")
+	}
+
+	plan, err := tpm.GetPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range plan.Steps {
+		if plan.Steps[i].ID == stepID {
+			return &plan.Steps[i], nil
+		}
+	}
+	return nil, fmt.Errorf("step %s not found in plan %s", stepID, planID)
+}
+
 func (tpm *TaskPlanManager) createStepExecutor(step TaskStep) StepExecutorFunc {
+	tpm.mu.RLock()
+	executor := tpm.stepExecutor
+	tpm.mu.RUnlock()
+
+	if executor != nil {
+		return executor
+	}
+
 	return func(ctx context.Context, s *TaskStep) (*StepOutput, error) {
 		time.Sleep(100 * time.Millisecond)
 		
@@ -429,6 +669,34 @@ func (tpm *TaskPlanManager) findReadySteps(steps []TaskStep, graph map[string][]
 	return ready
 }
 
+// ExecutionBatches groups the steps into the batches hybrid execution runs
+// them in: every step of a batch depends only on steps of earlier batches.
+// Steps left over by a dependency cycle are not returned.
+func ExecutionBatches(steps []TaskStep) [][]TaskStep {
+	var tpm TaskPlanManager
+	graph := tpm.buildDependencyGraph(steps)
+	executed := make(map[string]bool)
+
+	var batches [][]TaskStep
+	for len(executed) < len(steps) {
+		ready := tpm.findReadySteps(steps, graph, executed, map[string]bool{})
+		if len(ready) == 0 {
+			break
+		}
+
+		batch := make([]TaskStep, 0, len(ready))
+		for _, step := range ready {
+			batch = append(batch, *step)
+		}
+		for _, step := range ready {
+			executed[step.ID] = true
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
 func (tpm *TaskPlanManager) GetPlanProgress(ctx context.Context, planID string) (*PlanProgress, error) {
 	plan, err := tpm.GetPlan(ctx, planID)
 	if err != nil {
@@ -511,16 +779,23 @@ func (tpm *TaskPlanManager) validatePlan(plan *TaskPlan) error {
 
 func (tpm *TaskPlanManager) hasCyclicDependencies(steps []TaskStep) bool {
 	graph := make(map[string][]string)
+	nodes := make([]string, 0, len(steps))
 	for _, step := range steps {
 		graph[step.ID] = step.Dependencies
+		nodes = append(nodes, step.ID)
 	}
+	return hasCycle(nodes, graph)
+}
 
+// hasCycle reports whether the dependency graph (node -> dependencies)
+// contains a cycle reachable from nodes
+func hasCycle(nodes []string, graph map[string][]string) bool {
 	visited := make(map[string]bool)
 	recStack := make(map[string]bool)
 
-	for _, step := range steps {
-		if !visited[step.ID] {
-			if tpm.hasCyclicDependenciesUtil(step.ID, graph, visited, recStack) {
+	for _, node := range nodes {
+		if !visited[node] {
+			if hasCycleUtil(node, graph, visited, recStack) {
 				return true
 			}
 		}
@@ -529,15 +804,15 @@ func (tpm *TaskPlanManager) hasCyclicDependencies(steps []TaskStep) bool {
 	return false
 }
 
-func (tpm *TaskPlanManager) hasCyclicDependenciesUtil(stepID string, graph map[string][]string, visited, recStack map[string]bool) bool {
-	visited[stepID] = true
-	recStack[stepID] = true
+func hasCycleUtil(node string, graph map[string][]string, visited, recStack map[string]bool) bool {
+	visited[node] = true
+	recStack[node] = true
 
-	deps, exists := graph[stepID]
+	deps, exists := graph[node]
 	if exists && deps != nil {
 		for _, dep := range deps {
 			if !visited[dep] {
-				if tpm.hasCyclicDependenciesUtil(dep, graph, visited, recStack) {
+				if hasCycleUtil(dep, graph, visited, recStack) {
 					return true
 				}
 			} else if recStack[dep] {
@@ -546,7 +821,7 @@ func (tpm *TaskPlanManager) hasCyclicDependenciesUtil(stepID string, graph map[s
 		}
 	}
 
-	recStack[stepID] = false
+	recStack[node] = false
 	return false
 }
 
@@ -0,0 +1,302 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTaskPlanner is a rule-based TaskPlanner that decomposes a task into
+// the standard research / implementation / testing / review steps.
+type DefaultTaskPlanner struct {
+	baseStepTime time.Duration
+}
+
+// stepBlueprint describes a step before it is bound to a concrete task
+type stepBlueprint struct {
+	key          string
+	name         string
+	stepType     StepType
+	description  string
+	dependencies []string
+	weight       float64
+}
+
+// NewDefaultTaskPlanner creates a new rule-based task planner
+func NewDefaultTaskPlanner() *DefaultTaskPlanner {
+	return &DefaultTaskPlanner{
+		baseStepTime: 15 * time.Minute,
+	}
+}
+
+// AnalyzeTask estimates complexity and risks from the task description
+func (p *DefaultTaskPlanner) AnalyzeTask(ctx context.Context, task *Task) (*TaskAnalysis, error) {
+	if task == nil {
+		return nil, fmt.Errorf("task is nil")
+	}
+
+	text := strings.ToLower(task.Title + "\n" + task.Description)
+	analysis := &TaskAnalysis{
+		Complexity:   ComplexityLow,
+		Requirements: []string{},
+		Dependencies: []string{},
+		Risks:        []Risk{},
+		Suggestions:  []string{},
+	}
+
+	words := countWords(task.Description)
+	switch {
+	case words > 200:
+		analysis.Complexity = ComplexityHigh
+	case words > 50:
+		analysis.Complexity = ComplexityMedium
+	}
+
+	highComplexityKeywords := []string{"architecture", "migration", "security", "認証", "移行", "設計", "リファクタ", "refactor"}
+	for _, keyword := range highComplexityKeywords {
+		if strings.Contains(text, keyword) && analysis.Complexity == ComplexityLow {
+			analysis.Complexity = ComplexityMedium
+		}
+	}
+
+	for _, line := range strings.Split(task.Description, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") {
+			analysis.Requirements = append(analysis.Requirements, strings.TrimSpace(trimmed[2:]))
+		}
+	}
+
+	if analysis.Complexity == ComplexityHigh {
+		analysis.Risks = append(analysis.Risks, Risk{
+			Type:        "scope",
+			Description: "Task scope is large and may need further decomposition",
+			Impact:      "high",
+			Probability: 0.5,
+			Mitigation:  "Review the plan after the research step",
+		})
+		analysis.Suggestions = append(analysis.Suggestions, "Split the implementation into smaller deliverables")
+	}
+
+	if task.Type == TaskTypeBugFix {
+		analysis.Suggestions = append(analysis.Suggestions, "Add a regression test that reproduces the bug")
+	}
+
+	return analysis, nil
+}
+
+// CreatePlan builds a hybrid plan from the step blueprints for the task type
+func (p *DefaultTaskPlanner) CreatePlan(ctx context.Context, task *Task, analysis *TaskAnalysis) (*TaskPlan, error) {
+	if task == nil {
+		return nil, fmt.Errorf("task is nil")
+	}
+	if analysis == nil {
+		var err error
+		if analysis, err = p.AnalyzeTask(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
+	blueprints := p.blueprintsFor(task.Type)
+	multiplier := p.complexityMultiplier(analysis.Complexity)
+
+	stepIDs := make(map[string]string, len(blueprints))
+	for i, bp := range blueprints {
+		stepIDs[bp.key] = fmt.Sprintf("%s_step_%d", task.ID, i+1)
+	}
+
+	steps := make([]TaskStep, 0, len(blueprints))
+	var estimated time.Duration
+	for i, bp := range blueprints {
+		deps := make([]string, 0, len(bp.dependencies))
+		for _, dep := range bp.dependencies {
+			deps = append(deps, stepIDs[dep])
+		}
+
+		description := fmt.Sprintf("%s\n\nタスク: %s\n%s", bp.description, task.Title, task.Description)
+		if len(analysis.Requirements) > 0 && bp.stepType == StepTypeImplementation {
+			description += "\n\n要件:\n- " + strings.Join(analysis.Requirements, "\n- ")
+		}
+
+		steps = append(steps, TaskStep{
+			ID:           stepIDs[bp.key],
+			Name:         bp.name,
+			Type:         bp.stepType,
+			Description:  description,
+			Order:        i + 1,
+			Status:       TaskStatusPending,
+			ParentTaskID: task.ID,
+			Dependencies: deps,
+		})
+		estimated += time.Duration(float64(p.baseStepTime) * bp.weight * multiplier)
+	}
+
+	return &TaskPlan{
+		TaskID:        task.ID,
+		Strategy:      PlanStrategyHybrid,
+		Steps:         steps,
+		EstimatedTime: estimated,
+		SubTasks:      []SubTask{},
+		Dependencies:  []string{},
+	}, nil
+}
+
+// OptimizePlan picks the cheapest strategy that still honors the dependencies
+func (p *DefaultTaskPlanner) OptimizePlan(ctx context.Context, plan *TaskPlan) (*TaskPlan, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("plan is nil")
+	}
+
+	hasDependencies := false
+	for _, step := range plan.Steps {
+		if len(step.Dependencies) > 0 {
+			hasDependencies = true
+			break
+		}
+	}
+
+	switch {
+	case !hasDependencies:
+		plan.Strategy = PlanStrategyParallel
+	case p.isLinear(plan.Steps):
+		plan.Strategy = PlanStrategySequential
+	default:
+		plan.Strategy = PlanStrategyHybrid
+	}
+
+	sort.SliceStable(plan.Steps, func(i, j int) bool {
+		return plan.Steps[i].Order < plan.Steps[j].Order
+	})
+
+	return plan, nil
+}
+
+// ResolveDependencies builds a leveled dependency graph from task plans
+func (p *DefaultTaskPlanner) ResolveDependencies(ctx context.Context, tasks []*Task) (*DependencyGraph, error) {
+	graph := &DependencyGraph{
+		Nodes: []DependencyNode{},
+		Edges: []DependencyEdge{},
+	}
+
+	deps := make(map[string][]string)
+	for _, task := range tasks {
+		deps[task.ID] = []string{}
+		if task.Plan != nil {
+			deps[task.ID] = append(deps[task.ID], task.Plan.Dependencies...)
+		}
+	}
+
+	levels := make(map[string]int)
+	var levelOf func(id string, visiting map[string]bool) (int, error)
+	levelOf = func(id string, visiting map[string]bool) (int, error) {
+		if level, ok := levels[id]; ok {
+			return level, nil
+		}
+		if visiting[id] {
+			return 0, fmt.Errorf("cyclic dependency detected at task %s", id)
+		}
+		visiting[id] = true
+		level := 0
+		for _, dep := range deps[id] {
+			depLevel, err := levelOf(dep, visiting)
+			if err != nil {
+				return 0, err
+			}
+			if depLevel+1 > level {
+				level = depLevel + 1
+			}
+		}
+		visiting[id] = false
+		levels[id] = level
+		return level, nil
+	}
+
+	for _, task := range tasks {
+		level, err := levelOf(task.ID, make(map[string]bool))
+		if err != nil {
+			return nil, err
+		}
+		graph.Nodes = append(graph.Nodes, DependencyNode{TaskID: task.ID, Level: level})
+		for _, dep := range deps[task.ID] {
+			graph.Edges = append(graph.Edges, DependencyEdge{From: dep, To: task.ID, Type: "blocks"})
+		}
+	}
+
+	return graph, nil
+}
+
+// ValidatePlan checks step IDs, dependency references and cycles
+func (p *DefaultTaskPlanner) ValidatePlan(ctx context.Context, plan *TaskPlan) error {
+	if plan == nil {
+		return fmt.Errorf("plan is nil")
+	}
+	return (&TaskPlanManager{}).validatePlan(plan)
+}
+
+func (p *DefaultTaskPlanner) isLinear(steps []TaskStep) bool {
+	for _, step := range steps {
+		if len(step.Dependencies) > 1 {
+			return false
+		}
+	}
+
+	dependents := make(map[string]int)
+	for _, step := range steps {
+		for _, dep := range step.Dependencies {
+			dependents[dep]++
+			if dependents[dep] > 1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (p *DefaultTaskPlanner) complexityMultiplier(complexity ComplexityLevel) float64 {
+	switch complexity {
+	case ComplexityHigh:
+		return 2.0
+	case ComplexityMedium:
+		return 1.5
+	default:
+		return 1.0
+	}
+}
+
+func (p *DefaultTaskPlanner) blueprintsFor(taskType TaskType) []stepBlueprint {
+	switch taskType {
+	case TaskTypeBugFix:
+		return []stepBlueprint{
+			{key: "investigate", name: "原因調査", stepType: StepTypeResearch, description: "不具合の再現手順と根本原因を特定する", weight: 1.0},
+			{key: "fix", name: "不具合修正", stepType: StepTypeImplementation, description: "根本原因に対する修正を実装する", dependencies: []string{"investigate"}, weight: 1.5},
+			{key: "regression", name: "回帰テスト", stepType: StepTypeTesting, description: "再現テストを追加し既存テストが通ることを確認する", dependencies: []string{"fix"}, weight: 1.0},
+		}
+	case TaskTypeDocumentation:
+		return []stepBlueprint{
+			{key: "survey", name: "対象調査", stepType: StepTypeResearch, description: "ドキュメント対象のコードと既存ドキュメントを調査する", weight: 0.5},
+			{key: "write", name: "ドキュメント作成", stepType: StepTypeDocumentation, description: "ドキュメントを作成・更新する", dependencies: []string{"survey"}, weight: 1.5},
+			{key: "review", name: "レビュー", stepType: StepTypeReview, description: "記述内容の正確性と読みやすさをレビューする", dependencies: []string{"write"}, weight: 0.5},
+		}
+	case TaskTypeResearch:
+		return []stepBlueprint{
+			{key: "research", name: "調査", stepType: StepTypeResearch, description: "対象技術と既存コードベースを調査する", weight: 2.0},
+			{key: "report", name: "調査結果のまとめ", stepType: StepTypeDocumentation, description: "調査結果と推奨事項をまとめる", dependencies: []string{"research"}, weight: 0.5},
+		}
+	case TaskTypeRefactoring:
+		return []stepBlueprint{
+			{key: "analyze", name: "現状分析", stepType: StepTypeResearch, description: "リファクタリング対象の構造と依存関係を分析する", weight: 1.0},
+			{key: "safety", name: "保護テスト作成", stepType: StepTypeTesting, description: "リファクタリング前の振る舞いを固定するテストを用意する", dependencies: []string{"analyze"}, weight: 1.0},
+			{key: "refactor", name: "リファクタリング実施", stepType: StepTypeImplementation, description: "振る舞いを変えずに構造を改善する", dependencies: []string{"safety"}, weight: 2.0},
+			{key: "review", name: "レビュー", stepType: StepTypeReview, description: "変更差分と品質をレビューする", dependencies: []string{"refactor"}, weight: 0.5},
+		}
+	default:
+		return []stepBlueprint{
+			{key: "design", name: "調査・設計", stepType: StepTypeResearch, description: "既存コードを理解し実装方針を設計する", weight: 1.0},
+			{key: "implement", name: "実装", stepType: StepTypeImplementation, description: "設計に従って機能を実装する", dependencies: []string{"design"}, weight: 2.0},
+			{key: "docs", name: "ドキュメント更新", stepType: StepTypeDocumentation, description: "利用方法とAPIのドキュメントを更新する", dependencies: []string{"design"}, weight: 0.5},
+			{key: "test", name: "テスト", stepType: StepTypeTesting, description: "単体テストと統合テストを作成・実行する", dependencies: []string{"implement"}, weight: 1.0},
+			{key: "review", name: "レビュー", stepType: StepTypeReview, description: "成果物全体の品質をレビューする", dependencies: []string{"test", "docs"}, weight: 0.5},
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// QueuedMetadataKey marks tasks that were submitted to the queue, as opposed
+// to tasks created and planned directly by a deploy
+const QueuedMetadataKey = "queued"
+
+// ErrTaskNotQueued is returned when a task is not waiting in the queue
+var ErrTaskNotQueued = errors.New("task is not queued")
+
+// TaskQueue is a persistent queue of tasks waiting to be planned and
+// executed. Queued tasks are pending tasks in storage; they are taken in
+// order of priority and, within the same priority, oldest first.
+type TaskQueue struct {
+	storage Storage
+}
+
+func NewTaskQueue(storage Storage) *TaskQueue {
+	return &TaskQueue{
+		storage: storage,
+	}
+}
+
+// Add queues a new task
+func (q *TaskQueue) Add(ctx context.Context, req TaskRequest) (*Task, error) {
+	metadata := make(map[string]any, len(req.Metadata)+1)
+	for key, value := range req.Metadata {
+		metadata[key] = value
+	}
+	metadata[QueuedMetadataKey] = true
+	req.Metadata = metadata
+
+	task, err := NewTask(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.storage.SaveTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+	return task, nil
+}
+
+// List returns the queued tasks in the order they will be executed
+func (q *TaskQueue) List(ctx context.Context) ([]*Task, error) {
+	tasks, err := q.storage.ListTasks(ctx, TaskFilter{
+		Status: []TaskStatus{TaskStatusPending},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	queued := make([]*Task, 0, len(tasks))
+	for _, task := range tasks {
+		if IsQueued(task) {
+			queued = append(queued, task)
+		}
+	}
+
+	sort.SliceStable(queued, func(i, j int) bool {
+		if ri, rj := priorityRank(queued[i].Priority), priorityRank(queued[j].Priority); ri != rj {
+			return ri < rj
+		}
+		return queued[i].CreatedAt.Before(queued[j].CreatedAt)
+	})
+	return queued, nil
+}
+
+// Next returns the task to execute next, or nil when the queue is empty
+func (q *TaskQueue) Next(ctx context.Context) (*Task, error) {
+	tasks, err := q.List(ctx)
+	if err != nil || len(tasks) == 0 {
+		return nil, err
+	}
+	return tasks[0], nil
+}
+
+// Cancel removes a task from the queue. Tasks that already started are
+// cancelled through the orchestrator instead.
+func (q *TaskQueue) Cancel(ctx context.Context, taskID string) (*Task, error) {
+	task, err := q.storage.LoadTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if !IsQueued(task) || task.Status != TaskStatusPending {
+		return task, fmt.Errorf("%w: %s is %s", ErrTaskNotQueued, taskID, task.Status)
+	}
+
+	now := time.Now()
+	task.Status = TaskStatusCancelled
+	task.UpdatedAt = now
+	task.CompletedAt = &now
+	if err := q.storage.SaveTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+	return task, nil
+}
+
+// IsQueued reports whether the task was submitted through the queue
+func IsQueued(task *Task) bool {
+	queued, _ := task.Context.Metadata[QueuedMetadataKey].(bool)
+	return queued
+}
+
+// priorityRank orders priorities from the most to the least urgent
+func priorityRank(priority TaskPriority) int {
+	switch priority {
+	case TaskPriorityHigh:
+		return 0
+	case TaskPriorityMedium:
+		return 1
+	case TaskPriorityLow:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,100 @@
+package orchestrator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TaskSpecHeader is the optional YAML front-matter of a markdown task spec
+type TaskSpecHeader struct {
+	Title       string         `yaml:"title"`
+	Type        TaskType       `yaml:"type"`
+	Priority    TaskPriority   `yaml:"priority"`
+	ProjectPath string         `yaml:"project_path"`
+	Branch      string         `yaml:"branch"`
+	Metadata    map[string]any `yaml:"metadata"`
+}
+
+// ParseTaskSpec turns an issue-style markdown document into a task request.
+// The document may start with YAML front-matter between "---" lines; the
+// markdown body becomes the description. Without a title in the
+// front-matter, the first "# " heading of the body is used.
+//
+//	---
+//	type: bugfix
+//	priority: high
+//	branch: fix/login
+//	---
+//	# Login redirects to a 404
+//	...
+func ParseTaskSpec(data []byte) (TaskRequest, error) {
+	var header TaskSpecHeader
+	body := string(bytes.TrimPrefix(data, []byte("\ufeff")))
+
+	front, rest, found, err := splitFrontMatter(body)
+	if err != nil {
+		return TaskRequest{}, err
+	}
+	if found {
+		if err := yaml.Unmarshal([]byte(front), &header); err != nil {
+			return TaskRequest{}, fmt.Errorf("invalid front-matter: %w", err)
+		}
+		body = rest
+	}
+
+	if header.Type != "" && !header.Type.IsValid() {
+		return TaskRequest{}, fmt.Errorf("invalid front-matter: unknown task type %q", header.Type)
+	}
+	if header.Priority != "" && !header.Priority.IsValid() {
+		return TaskRequest{}, fmt.Errorf("invalid front-matter: unknown priority %q", header.Priority)
+	}
+
+	description := strings.TrimSpace(body)
+	if description == "" {
+		return TaskRequest{}, fmt.Errorf("task spec has no description")
+	}
+
+	title := strings.TrimSpace(header.Title)
+	if title == "" {
+		title = markdownTitle(description)
+	}
+
+	return TaskRequest{
+		Type:        header.Type,
+		Title:       title,
+		Description: description,
+		Priority:    header.Priority,
+		ProjectPath: header.ProjectPath,
+		Branch:      header.Branch,
+		Metadata:    header.Metadata,
+	}, nil
+}
+
+// splitFrontMatter separates a leading "---" block from the body
+func splitFrontMatter(doc string) (front, body string, found bool, err error) {
+	doc = strings.TrimLeft(doc, "\r\n")
+	lines := strings.SplitAfter(doc, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return "", doc, false, nil
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if line := strings.TrimSpace(lines[i]); line == "---" || line == "..." {
+			return strings.Join(lines[1:i], ""), strings.Join(lines[i+1:], ""), true, nil
+		}
+	}
+	return "", "", false, fmt.Errorf("front-matter is not closed with ---")
+}
+
+// markdownTitle returns the first level-one heading of a markdown document
+func markdownTitle(doc string) string {
+	for _, line := range strings.Split(doc, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+	}
+	return ""
+}
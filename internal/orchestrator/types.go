@@ -15,6 +15,15 @@ const (
 	TaskTypeResearch     TaskType = "research"
 )
 
+// IsValid reports whether t is one of the known task types
+func (t TaskType) IsValid() bool {
+	switch t {
+	case TaskTypeFeature, TaskTypeBugFix, TaskTypeRefactoring, TaskTypeDocumentation, TaskTypeResearch:
+		return true
+	}
+	return false
+}
+
 type TaskStatus string
 
 const (
@@ -33,6 +42,15 @@ const (
 	TaskPriorityLow    TaskPriority = "low"
 )
 
+// IsValid reports whether p is one of the known priorities
+func (p TaskPriority) IsValid() bool {
+	switch p {
+	case TaskPriorityHigh, TaskPriorityMedium, TaskPriorityLow:
+		return true
+	}
+	return false
+}
+
 type Task struct {
 	ID          string       `json:"id"`
 	Type        TaskType     `json:"type"`
@@ -78,6 +96,7 @@ const (
 type TaskStep struct {
 	ID           string       `json:"id"`
 	Name         string       `json:"name"`
+	Type         StepType     `json:"type"`
 	Description  string       `json:"description"`
 	Order        int          `json:"order"`
 	Status       TaskStatus   `json:"status"`
@@ -185,6 +204,8 @@ type TaskRequest struct {
 	Title       string                `json:"title"`
 	Description string                `json:"description"`
 	Priority    TaskPriority          `json:"priority"`
+	ProjectPath string                `json:"project_path,omitempty"` // defaults to the working directory
+	Branch      string                `json:"branch,omitempty"`
 	Context     context.Context       `json:"-"`
 	Metadata    map[string]any        `json:"metadata"`
 }
@@ -26,6 +26,11 @@ func NewOrchestratorPrompts() *OrchestratorPrompts {
 // OrchestratorData represents data for orchestrator prompts
 type OrchestratorData struct {
 	PaneID      string
+	SessionName string
+	ClaudeCmd   string // command that starts Claude in a new pane
+	Role        string // manager.role
+	RolePrompt  string // manager.prompt
+	Language    string // defaults.language
 	MainTask    string
 	Context     string
 	PaneList    []string
@@ -39,6 +44,12 @@ func (op *OrchestratorPrompts) registerTemplates() error {
 
 プロジェクトマネージャー({{.PaneID}})として機能してください。
 
+{{if .Role}}## 役割: {{.Role}}
+{{end}}{{if .RolePrompt}}{{.RolePrompt}}
+{{end}}{{if .Language}}
+## 言語
+サブタスクの指示・レビュー・報告は言語コード {{.Language}} の言語で記述してください
+{{end}}
 ## 制限事項
 禁止: コード編集、ファイル操作、ビルド、テスト、デプロイ、技術実装
 許可: コード解析、タスク分析・分解、割り当て、進捗管理、品質管理、統合判定
@@ -60,8 +71,8 @@ func (op *OrchestratorPrompts) registerTemplates() error {
 6. 統合テスト指示・完了判定
 
 ## ペイン操作
-**作成**: tmux split-window -v -t claude-squad
-**起動**: tmux send-keys -t 新ペインID 'claude --dangerously-skip-permissions' Enter
+**作成**: tmux split-window -v -t {{.SessionName}}
+**起動**: tmux send-keys -t 新ペインID '{{.ClaudeCmd}}' Enter
 **送信**: tmux send-keys -t 新ペインID Enter
 
 ## サブタスク送信
@@ -192,7 +203,7 @@ func (op *OrchestratorPrompts) GetAvailableTemplates() []string {
 // ValidatePromptVariables validates that required variables are present
 func (op *OrchestratorPrompts) ValidatePromptVariables(templateName string, variables map[string]interface{}) error {
 	requiredVars := map[string][]string{
-		"manager": {"PaneID", "MainTask"},
+		"manager": {"PaneID", "SessionName", "ClaudeCmd", "MainTask"},
 		"task_assignment": {"TaskDesc", "Context"},
 		"progress_check": {"TaskDesc"},
 		"review_request": {"TaskDesc"},
@@ -32,6 +32,7 @@ type StepData struct {
 	CompletionCriteria []string
 	ReportPane      string
 	ReportMessage   string
+	ReportCommand   string // 設定時は send-keys の代わりに報告コマンドを案内
 	Dependencies    []string
 	Context         string
 	Priority        string
@@ -60,7 +61,7 @@ func (st *StepTemplates) registerTemplates() error {
 {{if .Resources}}リソース:
 {{range .Resources}}- {{.}}
 {{end}}{{end}}
-報告方法: tmux send-keys -t {{.ReportPane}} '{{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter`
+{{if .ReportCommand}}報告方法: {{.ReportCommand}}{{else}}報告方法: tmux send-keys -t {{.ReportPane}} '{{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter{{end}}`
 
 	if err := st.RegisterTemplate("step_execution", stepTemplate); err != nil {
 		return err
@@ -96,7 +97,7 @@ func (st *StepTemplates) registerTemplates() error {
 {{range .Resources}}- {{.}}
 {{end}}{{end}}
 
-報告方法: tmux send-keys -t {{.ReportPane}} '実装完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter`
+{{if .ReportCommand}}報告方法: {{.ReportCommand}}{{else}}報告方法: tmux send-keys -t {{.ReportPane}} '実装完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter{{end}}`
 
 	if err := st.RegisterTemplate("code_implementation", codeTemplate); err != nil {
 		return err
@@ -128,7 +129,7 @@ func (st *StepTemplates) registerTemplates() error {
 {{.Context}}
 {{end}}
 
-報告方法: tmux send-keys -t {{.ReportPane}} 'テスト完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter`
+{{if .ReportCommand}}報告方法: {{.ReportCommand}}{{else}}報告方法: tmux send-keys -t {{.ReportPane}} 'テスト完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter{{end}}`
 
 	if err := st.RegisterTemplate("testing", testTemplate); err != nil {
 		return err
@@ -160,7 +161,7 @@ func (st *StepTemplates) registerTemplates() error {
 {{.Context}}
 {{end}}
 
-報告方法: tmux send-keys -t {{.ReportPane}} 'ドキュメント完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter`
+{{if .ReportCommand}}報告方法: {{.ReportCommand}}{{else}}報告方法: tmux send-keys -t {{.ReportPane}} 'ドキュメント完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter{{end}}`
 
 	if err := st.RegisterTemplate("documentation", docTemplate); err != nil {
 		return err
@@ -196,7 +197,7 @@ func (st *StepTemplates) registerTemplates() error {
 {{range .Resources}}- {{.}}
 {{end}}{{end}}
 
-報告方法: tmux send-keys -t {{.ReportPane}} '調査完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter`
+{{if .ReportCommand}}報告方法: {{.ReportCommand}}{{else}}報告方法: tmux send-keys -t {{.ReportPane}} '調査完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter{{end}}`
 
 	if err := st.RegisterTemplate("research", researchTemplate); err != nil {
 		return err
@@ -228,7 +229,7 @@ func (st *StepTemplates) registerTemplates() error {
 {{.Context}}
 {{end}}
 
-報告方法: tmux send-keys -t {{.ReportPane}} 'レビュー完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter`
+{{if .ReportCommand}}報告方法: {{.ReportCommand}}{{else}}報告方法: tmux send-keys -t {{.ReportPane}} 'レビュー完了: {{.StepName}} - {{.ReportMessage}}' Enter; sleep 1; tmux send-keys -t {{.ReportPane}} '' Enter{{end}}`
 
 	if err := st.RegisterTemplate("review", reviewTemplate); err != nil {
 		return err
@@ -342,8 +343,8 @@ func (st *StepTemplates) ValidateStepData(data StepData) error {
 		return fmt.Errorf("at least one completion criterion is required")
 	}
 	
-	if data.ReportPane == "" {
-		return fmt.Errorf("report pane is required")
+	if data.ReportPane == "" && data.ReportCommand == "" {
+		return fmt.Errorf("report pane or report command is required")
 	}
 	
 	return nil
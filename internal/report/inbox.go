@@ -0,0 +1,183 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Inbox is a spool directory that workers drop report files into. Reports
+// are written atomically and moved to processed/ once received.
+type Inbox struct {
+	dir          string
+	pollInterval time.Duration
+}
+
+// NewInbox opens (creating if needed) the inbox at dir
+func NewInbox(dir string) (*Inbox, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("inbox directory is required")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "processed"), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create inbox: %w", err)
+	}
+
+	return &Inbox{
+		dir:          dir,
+		pollInterval: 1 * time.Second,
+	}, nil
+}
+
+// Dir returns the spool directory
+func (i *Inbox) Dir() string {
+	return i.dir
+}
+
+// Submit writes a report into the inbox
+func (i *Inbox) Submit(report *Report) error {
+	if report.Timestamp.IsZero() {
+		report.Timestamp = time.Now()
+	}
+	if err := report.Validate(); err != nil {
+		return fmt.Errorf("invalid report: %w", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	name := fmt.Sprintf("%d-%s.json", report.Timestamp.UnixNano(), sanitize(report.StepID))
+	tmp, err := os.CreateTemp(i.dir, ".report-*")
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(i.dir, name)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to publish report: %w", err)
+	}
+	return nil
+}
+
+// Discard moves pending reports for the step to processed/ without returning
+// them, so a retried step does not pick up a stale report.
+func (i *Inbox) Discard(stepID string) error {
+	pending, err := i.pending()
+	if err != nil {
+		return err
+	}
+	for _, entry := range pending {
+		if entry.report.StepID == stepID {
+			i.archive(entry.path)
+		}
+	}
+	return nil
+}
+
+// Receive blocks until a report for the step arrives or ctx is done
+func (i *Inbox) Receive(ctx context.Context, stepID string) (*Report, error) {
+	ticker := time.NewTicker(i.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		pending, err := i.pending()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range pending {
+			if entry.report.StepID == stepID {
+				i.archive(entry.path)
+				return entry.report, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Pending returns all reports not yet received, oldest first
+func (i *Inbox) Pending() ([]*Report, error) {
+	pending, err := i.pending()
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*Report, 0, len(pending))
+	for _, entry := range pending {
+		reports = append(reports, entry.report)
+	}
+	return reports, nil
+}
+
+type spoolEntry struct {
+	path   string
+	report *Report
+}
+
+func (i *Inbox) pending() ([]spoolEntry, error) {
+	entries, err := os.ReadDir(i.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inbox: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	pending := make([]spoolEntry, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(i.dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var report Report
+		if err := json.Unmarshal(data, &report); err != nil {
+			// Unparseable files are set aside rather than retried forever
+			i.archive(path)
+			continue
+		}
+		pending = append(pending, spoolEntry{path: path, report: &report})
+	}
+	return pending, nil
+}
+
+func (i *Inbox) archive(path string) {
+	os.Rename(path, filepath.Join(i.dir, "processed", filepath.Base(path)))
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
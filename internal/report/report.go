@@ -0,0 +1,79 @@
+// Package report carries structured completion reports from Claude workers
+// back to the orchestrator through a spool-directory inbox.
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"claude-company/internal/orchestrator"
+)
+
+// 報告ステータス
+const (
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// Report is a worker's completion report for one step
+type Report struct {
+	StepID    string    `json:"step_id"`
+	Status    string    `json:"status"`
+	Summary   string    `json:"summary"`
+	Details   string    `json:"details,omitempty"`
+	Artifacts []string  `json:"artifacts,omitempty"`
+	Pane      string    `json:"pane,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Validate checks the required fields and the status value
+func (r *Report) Validate() error {
+	if r.StepID == "" {
+		return fmt.Errorf("step ID is required")
+	}
+	if r.Status != StatusCompleted && r.Status != StatusFailed {
+		return fmt.Errorf("invalid status %q (expected %s or %s)", r.Status, StatusCompleted, StatusFailed)
+	}
+	if r.Summary == "" {
+		return fmt.Errorf("summary is required")
+	}
+	return nil
+}
+
+// Failed reports whether the worker reported a failure
+func (r *Report) Failed() bool {
+	return r.Status == StatusFailed
+}
+
+// StepOutput converts a successful report into a step output
+func (r *Report) StepOutput() *orchestrator.StepOutput {
+	data := map[string]any{
+		"step_id":     r.StepID,
+		"status":      r.Status,
+		"reported_at": r.Timestamp,
+	}
+	if r.Details != "" {
+		data["details"] = r.Details
+	}
+	if len(r.Artifacts) > 0 {
+		data["artifacts"] = r.Artifacts
+	}
+	if r.Pane != "" {
+		data["pane"] = r.Pane
+	}
+
+	return &orchestrator.StepOutput{
+		Type:    "worker_report",
+		Content: r.Summary,
+		Data:    data,
+	}
+}
+
+// StepError converts a failure report into a step error
+func (r *Report) StepError() *orchestrator.StepError {
+	return &orchestrator.StepError{
+		Code:    "worker_reported_failure",
+		Message: r.Summary,
+		Details: r.Details,
+	}
+}
@@ -0,0 +1,162 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrDeliveryUnconfirmed is returned by PasteDelivery.Send when pasted text
+// did not show up in the pane, in which case Enter is not pressed
+var ErrDeliveryUnconfirmed = errors.New("pasted text did not appear in the pane")
+
+// pastePlaceholderPattern matches the placeholder Claude shows in place of a
+// long paste, e.g. "[Pasted text #1 +42 lines]"
+var pastePlaceholderPattern = regexp.MustCompile(`\[Pasted text #\d+`)
+
+// PasteDelivery types prompts into panes. Rather than send-keys, which reads
+// words such as "Enter" as key names and submits at every newline, the text
+// goes through a paste buffer as a bracketed paste. Large prompts are pasted
+// in chunks, and each chunk must show up in the pane before the next one is
+// pasted and Enter is finally pressed.
+type PasteDelivery struct {
+	manager   *Manager
+	ChunkSize int // bytes per paste
+	Timeout   time.Duration
+	Interval  time.Duration
+}
+
+// NewPasteDelivery creates a delivery with the default chunk size and timeout
+func NewPasteDelivery(manager *Manager) *PasteDelivery {
+	return &PasteDelivery{
+		manager:   manager,
+		ChunkSize: 8 * 1024,
+		Timeout:   10 * time.Second,
+		Interval:  200 * time.Millisecond,
+	}
+}
+
+// Send pastes text into the target pane (or window) and submits it with Enter
+func (d *PasteDelivery) Send(ctx context.Context, target, text string) error {
+	for _, chunk := range splitChunks(text, d.ChunkSize) {
+		if err := d.paste(ctx, target, chunk); err != nil {
+			return err
+		}
+	}
+	if err := d.manager.mux.SendKeys(target, "Enter"); err != nil {
+		return fmt.Errorf("failed to submit prompt to %s: %w", target, err)
+	}
+	return nil
+}
+
+// paste pastes one chunk and waits until the pane shows it
+func (d *PasteDelivery) paste(ctx context.Context, target, chunk string) error {
+	before, err := d.manager.CapturePane(target, 200)
+	if err != nil {
+		return err
+	}
+	if err := d.manager.mux.Paste(target, chunk); err != nil {
+		return fmt.Errorf("failed to paste into %s: %w", target, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		after, err := d.manager.CapturePane(target, 200)
+		if err != nil {
+			return err
+		}
+		if pasteLanded(before, after, chunk) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("%w after %s in %s", ErrDeliveryUnconfirmed, d.Timeout, target)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// pasteLanded reports whether the pane content after a paste shows the chunk:
+// Claude added a paste placeholder, or the chunk's tail appears more often
+// than before or near the end of the changed screen. Whitespace and box
+// borders are ignored, since the input box wraps and indents the text.
+func pasteLanded(before, after, chunk string) bool {
+	placeholders := len(pastePlaceholderPattern.FindAllStringIndex(before, -1))
+	if len(pastePlaceholderPattern.FindAllStringIndex(after, -1)) > placeholders {
+		return true
+	}
+
+	tail := []rune(normalizeScreen(chunk))
+	if len(tail) == 0 {
+		return true
+	}
+	if len(tail) > 24 {
+		tail = tail[len(tail)-24:]
+	}
+
+	beforeScreen, afterScreen := normalizeScreen(before), []rune(normalizeScreen(after))
+	if strings.Count(string(afterScreen), string(tail)) > strings.Count(beforeScreen, string(tail)) {
+		return true
+	}
+	// The scrollback may have dropped an earlier copy of a repetitive tail;
+	// the text just pasted is still at the end, above at most a footer
+	if string(afterScreen) == beforeScreen {
+		return false
+	}
+	if end := len(tail) + 256; len(afterScreen) > end {
+		afterScreen = afterScreen[len(afterScreen)-end:]
+	}
+	return strings.Contains(string(afterScreen), string(tail))
+}
+
+// normalizeScreen drops whitespace and the input box's vertical borders
+func normalizeScreen(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '│' {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// splitChunks splits text into pieces of at most size bytes, preferably after
+// a newline and never inside a UTF-8 sequence
+func splitChunks(text string, size int) []string {
+	if size <= 0 || len(text) <= size {
+		return []string{text}
+	}
+
+	var chunks []string
+	for len(text) > size {
+		cut := strings.LastIndexByte(text[:size], '\n') + 1
+		if cut == 0 {
+			cut = size
+			for cut > 0 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+			if cut == 0 {
+				_, cut = utf8.DecodeRuneInString(text)
+			}
+		}
+		chunks = append(chunks, text[:cut])
+		text = text[cut:]
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/prompts"
+)
+
+// PlanPreview is the plan a task would run with, as produced by PreviewPlan
+type PlanPreview struct {
+	Task *orchestrator.Task
+	Plan *orchestrator.TaskPlan
+	// Prompts maps step IDs to the prompt their worker would receive
+	Prompts map[string]string
+}
+
+// PreviewPlan runs the plan creation path for req without touching tmux or
+// durable storage: the task is planned by an in-memory orchestrator and each
+// step's prompt is rendered as it would be sent to a worker pane.
+func (m *Manager) PreviewPlan(ctx context.Context, req orchestrator.TaskRequest) (*PlanPreview, error) {
+	orch := orchestrator.NewTaskOrchestrator(m.orchestratorConfig(), nil, nil, nil)
+
+	resp, err := orch.CreateTask(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+	task, err := orch.GetTask(ctx, resp.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := orch.CreatePlan(ctx, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plan: %w", err)
+	}
+
+	// Workers report through the inbox, so no report pane is needed
+	executor := &StepExecutor{
+		manager:   m,
+		templates: prompts.NewStepTemplates(),
+		waiter:    &InboxReportWaiter{},
+	}
+	preview := &PlanPreview{
+		Task:    task,
+		Plan:    plan,
+		Prompts: make(map[string]string, len(plan.Steps)),
+	}
+	for i := range plan.Steps {
+		step := &plan.Steps[i]
+		prompt, err := executor.BuildPrompt(step)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build prompt for step %s: %w", step.ID, err)
+		}
+		preview.Prompts[step.ID] = prompt
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,620 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FakeMultiplexer is an in-memory Multiplexer for exercising a Manager without
+// a tmux server. It records every command and simulates the panes: keys sent
+// and text pasted to a pane are typed into it and echoed below its output,
+// and Enter hands the typed input to the responder (see SetResponder and
+// EmulateClaude), which may write output or change the pane's foreground
+// command.
+type FakeMultiplexer struct {
+	mu         sync.Mutex
+	windows    []*fakeWindow
+	panes      []*FakePane
+	nextWindow int
+	nextPane   int
+	selected   string
+	commands   [][]string
+	sent       map[string][]string
+	respond    func(pane *FakePane, input string)
+}
+
+var _ Multiplexer = (*FakeMultiplexer)(nil)
+
+type fakeWindow struct {
+	id      string
+	session string
+	name    string
+	index   int
+	layout  string
+	options map[string]string
+}
+
+// FakePane is a simulated pane of a FakeMultiplexer
+type FakePane struct {
+	PaneInfo
+	Session  string
+	WindowID string
+	lines    []string
+	input    string
+}
+
+// Write appends text to the pane's scrollback
+func (p *FakePane) Write(text string) {
+	p.lines = append(p.lines, strings.Split(strings.TrimSuffix(text, "\n"), "\n")...)
+}
+
+// NewFakeMultiplexer creates a fake with no sessions
+func NewFakeMultiplexer() *FakeMultiplexer {
+	return &FakeMultiplexer{sent: make(map[string][]string)}
+}
+
+// fakeClaudeScreen is drawn by EmulateClaude once Claude has "started"
+const fakeClaudeScreen = "╭──────────────────────────────╮\n│ > \n╰──────────────────────────────╯\n  ? for shortcuts"
+
+// SetResponder sets the function called with each line entered in a pane.
+// It runs with the fake locked: it may change the pane but must not call the
+// multiplexer.
+func (f *FakeMultiplexer) SetResponder(respond func(pane *FakePane, input string)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.respond = respond
+}
+
+// EmulateClaude makes panes start "Claude" when claudeCmd is entered: the
+// foreground command becomes claude and the input box is drawn, which
+// satisfies the readiness probe. Other input is echoed.
+func (f *FakeMultiplexer) EmulateClaude(claudeCmd string) {
+	f.SetResponder(func(pane *FakePane, input string) {
+		if input == claudeCmd {
+			pane.Command = "claude"
+			pane.Write(fakeClaudeScreen)
+			return
+		}
+		pane.Write("> " + input)
+	})
+}
+
+// Commands returns the commands run so far, each as tmux arguments
+// (e.g. ["send-keys", "-t", "%1", "Enter"])
+func (f *FakeMultiplexer) Commands() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	commands := make([][]string, len(f.commands))
+	copy(commands, f.commands)
+	return commands
+}
+
+// Sent returns the lines entered in a pane, in order
+func (f *FakeMultiplexer) Sent(paneID string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.sent[paneID]...)
+}
+
+// Pending returns the input typed or pasted into a pane and not yet entered
+func (f *FakeMultiplexer) Pending(paneID string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if pane := f.resolve(paneID); pane != nil {
+		return pane.input
+	}
+	return ""
+}
+
+// WritePane appends text to a pane's scrollback, as if its process printed it
+func (f *FakeMultiplexer) WritePane(paneID, text string) error {
+	return f.updatePane(paneID, func(pane *FakePane) { pane.Write(text) })
+}
+
+// SetPaneCommand changes a pane's foreground command
+func (f *FakeMultiplexer) SetPaneCommand(paneID, command string) error {
+	return f.updatePane(paneID, func(pane *FakePane) { pane.Command = command })
+}
+
+// SetPaneDead marks a pane's process as exited
+func (f *FakeMultiplexer) SetPaneDead(paneID string, dead bool) error {
+	return f.updatePane(paneID, func(pane *FakePane) { pane.Dead = dead })
+}
+
+// WindowNames returns the names of the session's windows in order
+func (f *FakeMultiplexer) WindowNames(session string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var names []string
+	for _, window := range f.windows {
+		if window.session == session {
+			names = append(names, window.name)
+		}
+	}
+	return names
+}
+
+// WindowLayout returns the layout last selected for the target's window
+func (f *FakeMultiplexer) WindowLayout(target string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if pane := f.resolve(target); pane != nil {
+		return f.window(pane.WindowID).layout
+	}
+	return ""
+}
+
+// SelectedPane returns the pane last selected with SelectPane
+func (f *FakeMultiplexer) SelectedPane() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.selected
+}
+
+func (f *FakeMultiplexer) updatePane(paneID string, update func(pane *FakePane)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pane := f.resolve(paneID)
+	if pane == nil {
+		return fmt.Errorf("can't find pane: %s", paneID)
+	}
+	update(pane)
+	return nil
+}
+
+func (f *FakeMultiplexer) Check() error {
+	return nil
+}
+
+func (f *FakeMultiplexer) HasSession(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("has-session", "-t", name)
+	return f.hasSession(name)
+}
+
+func (f *FakeMultiplexer) NewSession(name, windowName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("new-session", "-d", "-s", name, "-n", windowName)
+	if f.hasSession(name) {
+		return fmt.Errorf("duplicate session: %s", name)
+	}
+	f.addWindow(name, windowName)
+	return nil
+}
+
+func (f *FakeMultiplexer) ListSessions() ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("list-sessions")
+
+	var sessions []string
+	for _, window := range f.windows {
+		if !containsString(sessions, window.session) {
+			sessions = append(sessions, window.session)
+		}
+	}
+	sort.Strings(sessions)
+	return sessions, nil
+}
+
+func (f *FakeMultiplexer) KillSession(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("kill-session", "-t", name)
+	if !f.hasSession(name) {
+		return fmt.Errorf("can't find session: %s", name)
+	}
+
+	windows := f.windows[:0]
+	for _, window := range f.windows {
+		if window.session != name {
+			windows = append(windows, window)
+		}
+	}
+	f.windows = windows
+	panes := f.panes[:0]
+	for _, pane := range f.panes {
+		if pane.Session != name {
+			panes = append(panes, pane)
+		}
+	}
+	f.panes = panes
+	return nil
+}
+
+func (f *FakeMultiplexer) RenameSession(name, newName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("rename-session", "-t", name, newName)
+	if !f.hasSession(name) {
+		return fmt.Errorf("can't find session: %s", name)
+	}
+	if f.hasSession(newName) {
+		return fmt.Errorf("duplicate session: %s", newName)
+	}
+
+	for _, window := range f.windows {
+		if window.session == name {
+			window.session = newName
+		}
+	}
+	for _, pane := range f.panes {
+		if pane.Session == name {
+			pane.Session = newName
+		}
+	}
+	return nil
+}
+
+func (f *FakeMultiplexer) NewWindow(session, name string) (string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("new-window", "-d", "-P", "-F", "#{window_id} #{pane_id}", "-t", session+":", "-n", name)
+	if !f.hasSession(session) {
+		return "", "", fmt.Errorf("can't find session: %s", session)
+	}
+	window := f.addWindow(session, name)
+	return window.id, f.panes[len(f.panes)-1].ID, nil
+}
+
+func (f *FakeMultiplexer) Split(target string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("split-window", "-d", "-v", "-P", "-F", "#{pane_id}", "-t", target)
+	pane := f.resolve(target)
+	if pane == nil {
+		return "", fmt.Errorf("can't find pane: %s", target)
+	}
+	return f.addPane(f.window(pane.WindowID)).ID, nil
+}
+
+func (f *FakeMultiplexer) SelectLayout(target, layout string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("select-layout", "-t", target, layout)
+	pane := f.resolve(target)
+	if pane == nil {
+		return fmt.Errorf("can't find window: %s", target)
+	}
+	f.window(pane.WindowID).layout = layout
+	return nil
+}
+
+func (f *FakeMultiplexer) SetPaneTitle(target, title string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("select-pane", "-t", target, "-T", title)
+	pane := f.resolve(target)
+	if pane == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	pane.Title = title
+	return nil
+}
+
+func (f *FakeMultiplexer) RenameWindow(target, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("rename-window", "-t", target, name)
+	pane := f.resolve(target)
+	if pane == nil {
+		return fmt.Errorf("can't find window: %s", target)
+	}
+	f.window(pane.WindowID).name = name
+	return nil
+}
+
+func (f *FakeMultiplexer) SetWindowOption(target, option, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("set-option", "-w", "-t", target, option, value)
+	pane := f.resolve(target)
+	if pane == nil {
+		return fmt.Errorf("can't find window: %s", target)
+	}
+	f.window(pane.WindowID).options[option] = value
+	return nil
+}
+
+func (f *FakeMultiplexer) ListPanes(session string) ([]PaneInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if session == "" {
+		f.record("list-panes", "-a")
+	} else {
+		f.record("list-panes", "-s", "-t", session)
+		if !f.hasSession(session) {
+			return nil, fmt.Errorf("can't find session: %s", session)
+		}
+	}
+
+	var panes []PaneInfo
+	for _, pane := range f.sortedPanes() {
+		if session == "" || pane.Session == session {
+			panes = append(panes, pane.PaneInfo)
+		}
+	}
+	return panes, nil
+}
+
+func (f *FakeMultiplexer) ListWindows(session string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("list-windows", "-t", session)
+	if !f.hasSession(session) {
+		return nil, fmt.Errorf("can't find session: %s", session)
+	}
+
+	var windows []string
+	for _, window := range f.windows {
+		if window.session == session {
+			windows = append(windows, window.id)
+		}
+	}
+	return windows, nil
+}
+
+func (f *FakeMultiplexer) DescribePane(target string) (*PaneInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("display-message", "-p", "-t", target)
+	pane := f.resolve(target)
+	if pane == nil {
+		return nil, nil
+	}
+	info := pane.PaneInfo
+	return &info, nil
+}
+
+func (f *FakeMultiplexer) SendKeys(target string, keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record(append([]string{"send-keys", "-t", target}, keys...)...)
+	pane := f.resolve(target)
+	if pane == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+
+	for _, key := range keys {
+		switch key {
+		case "Enter", "C-m":
+			input := pane.input
+			pane.input = ""
+			f.sent[pane.ID] = append(f.sent[pane.ID], input)
+			if f.respond != nil {
+				f.respond(pane, input)
+			}
+		case "Escape", "C-c":
+			pane.input = ""
+		default:
+			pane.input += key
+		}
+	}
+	return nil
+}
+
+func (f *FakeMultiplexer) Paste(target, text string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("paste-buffer", "-d", "-p", "-r", "-t", target)
+	pane := f.resolve(target)
+	if pane == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	pane.input += text
+	return nil
+}
+
+func (f *FakeMultiplexer) Capture(target string, lines int) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("capture-pane", "-t", target, "-p")
+	pane := f.resolve(target)
+	if pane == nil {
+		return "", fmt.Errorf("can't find pane: %s", target)
+	}
+
+	captured := pane.lines
+	if pane.input != "" {
+		captured = append(captured[:len(captured):len(captured)], strings.Split(pane.input, "\n")...)
+	}
+	if lines > 0 && len(captured) > lines {
+		captured = captured[len(captured)-lines:]
+	}
+	if len(captured) == 0 {
+		return "", nil
+	}
+	return strings.Join(captured, "\n") + "\n", nil
+}
+
+func (f *FakeMultiplexer) SelectPane(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("select-pane", "-t", target)
+	pane := f.resolve(target)
+	if pane == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	f.selected = pane.ID
+	return nil
+}
+
+func (f *FakeMultiplexer) KillPane(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("kill-pane", "-t", target)
+	pane := f.resolve(target)
+	if pane == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+
+	// Like tmux, the panes left in the window are renumbered and the window
+	// goes away with its last pane
+	remaining := f.panes[:0]
+	index := 0
+	for _, p := range f.sortedPanes() {
+		if p == pane {
+			continue
+		}
+		remaining = append(remaining, p)
+		if p.WindowID == pane.WindowID {
+			p.Index = index
+			index++
+		}
+	}
+	f.panes = remaining
+
+	if index == 0 {
+		windows := f.windows[:0]
+		for _, window := range f.windows {
+			if window.id != pane.WindowID {
+				windows = append(windows, window)
+			}
+		}
+		f.windows = windows
+	}
+	return nil
+}
+
+func (f *FakeMultiplexer) RespawnPane(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("respawn-pane", "-k", "-t", target)
+	pane := f.resolve(target)
+	if pane == nil {
+		return fmt.Errorf("can't find pane: %s", target)
+	}
+	pane.Command = "bash"
+	pane.Dead = false
+	pane.input = ""
+	return nil
+}
+
+func (f *FakeMultiplexer) Attach(session string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("attach-session", "-t", session)
+	if !f.hasSession(session) {
+		return fmt.Errorf("can't find session: %s", session)
+	}
+	return nil
+}
+
+func (f *FakeMultiplexer) record(args ...string) {
+	f.commands = append(f.commands, args)
+}
+
+func (f *FakeMultiplexer) hasSession(name string) bool {
+	for _, window := range f.windows {
+		if window.session == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FakeMultiplexer) window(id string) *fakeWindow {
+	for _, window := range f.windows {
+		if window.id == id {
+			return window
+		}
+	}
+	return nil
+}
+
+func (f *FakeMultiplexer) addWindow(session, name string) *fakeWindow {
+	index := 0
+	for _, window := range f.windows {
+		if window.session == session && window.index >= index {
+			index = window.index + 1
+		}
+	}
+	window := &fakeWindow{
+		id:      fmt.Sprintf("@%d", f.nextWindow),
+		session: session,
+		name:    name,
+		index:   index,
+		options: make(map[string]string),
+	}
+	f.nextWindow++
+	f.windows = append(f.windows, window)
+	f.addPane(window)
+	return window
+}
+
+func (f *FakeMultiplexer) addPane(window *fakeWindow) *FakePane {
+	index := 0
+	for _, pane := range f.panes {
+		if pane.WindowID == window.id {
+			index++
+		}
+	}
+	pane := &FakePane{
+		PaneInfo: PaneInfo{
+			ID:      fmt.Sprintf("%%%d", f.nextPane),
+			Window:  strconv.Itoa(window.index),
+			Index:   index,
+			Command: "bash",
+		},
+		Session:  window.session,
+		WindowID: window.id,
+	}
+	f.panes = append(f.panes, pane)
+	f.nextPane++
+	return pane
+}
+
+// sortedPanes orders panes like tmux lists them: by window, then pane index
+func (f *FakeMultiplexer) sortedPanes() []*FakePane {
+	panes := append([]*FakePane(nil), f.panes...)
+	sort.SliceStable(panes, func(i, j int) bool {
+		if panes[i].Session != panes[j].Session {
+			return panes[i].Session < panes[j].Session
+		}
+		wi, _ := strconv.Atoi(panes[i].Window)
+		wj, _ := strconv.Atoi(panes[j].Window)
+		if wi != wj {
+			return wi < wj
+		}
+		return panes[i].Index < panes[j].Index
+	})
+	return panes
+}
+
+// resolve finds the pane a target names: a pane ID, a window ID, a session
+// or session:window.pane. Windows and sessions resolve to their first pane.
+func (f *FakeMultiplexer) resolve(target string) *FakePane {
+	panes := f.sortedPanes()
+	switch {
+	case strings.HasPrefix(target, "%"):
+		for _, pane := range panes {
+			if pane.ID == target {
+				return pane
+			}
+		}
+	case strings.HasPrefix(target, "@"):
+		for _, pane := range panes {
+			if pane.WindowID == target {
+				return pane
+			}
+		}
+	default:
+		session, rest, _ := strings.Cut(target, ":")
+		window, index, hasIndex := strings.Cut(rest, ".")
+		for _, pane := range panes {
+			if pane.Session != session || (window != "" && pane.Window != window) {
+				continue
+			}
+			if hasIndex && strconv.Itoa(pane.Index) != index {
+				continue
+			}
+			return pane
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package session
+
+import (
+	"fmt"
+
+	"claude-company/internal/config"
+)
+
+// layout returns the configured worker layout
+func (m *Manager) layout() string {
+	if m.config.Session.Layout == "" {
+		return config.LayoutTiled
+	}
+	return m.config.Session.Layout
+}
+
+// openWorkerPane adds a pane for a new worker and returns its ID: a split of
+// the parent window in the tiled and main-vertical layouts, or a window of
+// its own
+func (m *Manager) openWorkerPane() (string, error) {
+	if m.layout() == config.LayoutWindows {
+		_, paneID, err := m.mux.NewWindow(m.SessionName, m.config.Session.WindowPrefix)
+		return paneID, err
+	}
+
+	window, err := m.parentWindow()
+	if err != nil {
+		return "", err
+	}
+	return m.mux.Split(window)
+}
+
+// parentWindow is the first window of the session, which holds the parent
+// panes and, unless each worker has a window, the worker panes
+func (m *Manager) parentWindow() (string, error) {
+	panes, err := m.mux.ListPanes(m.SessionName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get panes: %v", err)
+	}
+	if len(panes) == 0 {
+		return "", fmt.Errorf("no panes found in session %s", m.SessionName)
+	}
+	return m.SessionName + ":" + panes[0].Window, nil
+}
+
+// arrangeWindow applies the configured layout to the window holding target,
+// so that the next split has room, and shows pane labels in the borders
+func (m *Manager) arrangeWindow(target string) error {
+	if err := m.mux.SetWindowOption(target, "pane-border-status", "top"); err != nil {
+		return err
+	}
+	if err := m.mux.SetWindowOption(target, "pane-border-format", " "+paneTitleFormat+" "); err != nil {
+		return err
+	}
+	if layout := m.layout(); layout != config.LayoutWindows {
+		return m.mux.SelectLayout(target, layout)
+	}
+	return nil
+}
+
+// LabelWorker titles a worker pane with the pane prefix, the worker name and
+// its role. In the windows layout the worker's window is named likewise.
+func (m *Manager) LabelWorker(paneID, name, role string) error {
+	session := m.config.Session
+	if err := m.mux.SetPaneTitle(paneID, workerLabel(session.PanePrefix, name, role)); err != nil {
+		return err
+	}
+	if m.layout() != config.LayoutWindows {
+		return nil
+	}
+
+	// A pane sharing its window (e.g. adopted from the parent window) leaves
+	// the window name alone
+	pane, err := m.mux.DescribePane(paneID)
+	if err != nil || pane == nil {
+		return err
+	}
+	panes, err := m.mux.ListPanes(m.SessionName)
+	if err != nil {
+		return err
+	}
+	for _, other := range panes {
+		if other.Window == pane.Window && other.ID != paneID {
+			return nil
+		}
+	}
+	return m.mux.RenameWindow(paneID, workerLabel(session.WindowPrefix, name, role))
+}
+
+// workerLabel formats a pane title or window name, e.g. "claude-worker-1 (tester)"
+func workerLabel(prefix, name, role string) string {
+	label := name
+	if prefix != "" {
+		label = prefix + "-" + name
+	}
+	if role != "" {
+		label += " (" + role + ")"
+	}
+	return label
+}
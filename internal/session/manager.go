@@ -2,32 +2,61 @@ package session
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"claude-company/internal/config"
 	"claude-company/internal/orchestrator"
 )
 
+// DataDirName is the per-project directory holding persisted orchestrator state
+const DataDirName = ".claude-company"
+
+// DefaultClaudeCmd is used when no Claude command is configured
+const DefaultClaudeCmd = "claude --dangerously-skip-permissions"
+
+// Storage backends selectable with SetStorageBackend
+const (
+	StorageBackendFile   = "file"
+	StorageBackendSQLite = "sqlite"
+)
+
 type Manager struct {
 	SessionName      string
 	ClaudeCmd        string
-	ParentPanes      map[string]bool               // 親ペイン追跡マップ
-	InitialPanes     []string                      // 初期ペイン状態
-	ParentWindows    map[string]bool               // 親ウィンドウ追跡マップ
-	InitialWindows   []string                      // 初期ウィンドウ状態
-	mainTask         string                        // メインタスク
-	orchestratorMode bool                          // オーケストレーターモードフラグ
-	orchestrator     orchestrator.Orchestrator     // オーケストレーターインスタンス
-	currentTask      *orchestrator.Task            // 現在実行中のタスク
-	stepManager      *orchestrator.StepManager     // ステップマネージャー
-	taskPlanManager  *orchestrator.TaskPlanManager // タスクプランマネージャー
+	ParentPanes      map[string]bool                 // 親ペイン追跡マップ
+	InitialPanes     []string                        // 初期ペイン状態
+	ParentWindows    map[string]bool                 // 親ウィンドウ追跡マップ
+	InitialWindows   []string                        // 初期ウィンドウ状態
+	mainTask         string                          // メインタスク
+	orchestratorMode bool                            // オーケストレーターモードフラグ
+	orchestrator     orchestrator.Orchestrator       // オーケストレーターインスタンス
+	currentTask      *orchestrator.Task              // 現在実行中のタスク
+	stepManager      *orchestrator.StepManager       // ステップマネージャー
+	taskPlanManager  *orchestrator.TaskPlanManager   // タスクプランマネージャー
+	taskQueue        *orchestrator.TaskQueue         // 実行待ちタスクのキュー
+	storageBackend   string                          // 永続化バックエンド (file / sqlite)
+	eventBus         *orchestrator.InProcessEventBus // イベントバス
+	stepExecutor     *StepExecutor                   // ワーカーペインへのステップ実行
+	readiness        *ReadinessProbe                 // Claude 起動待ち
+	delivery         *PasteDelivery                  // ペインへのプロンプト送信
+	workerPool       *PaneWorkerPool                 // 子ペインのワーカープール
+	quietEvents      bool                            // イベントを標準出力に表示しない
+	interruptWorkers bool                            // 終了時に作業中のワーカーを中断する
+	config           *config.OrchestratorConfig      // 解決済みの設定
+	mux              Multiplexer                     // ペインを操作する端末マルチプレクサ
+	createMu         sync.Mutex                      // ペイン・ウィンドウの作成を直列化
 }
 
 func NewManager(sessionName, claudeCmd string) *Manager {
-	return &Manager{
+	m := &Manager{
 		SessionName:      sessionName,
 		ClaudeCmd:        claudeCmd,
 		ParentPanes:      make(map[string]bool),
@@ -36,7 +65,39 @@ func NewManager(sessionName, claudeCmd string) *Manager {
 		InitialWindows:   []string{},
 		mainTask:         "",
 		orchestratorMode: false,
+		storageBackend:   StorageBackendFile,
+		config:           config.NewOrchestratorConfig(),
+		mux:              NewTmuxMultiplexer(),
 	}
+	m.config.Session.Name = sessionName
+	m.readiness = NewReadinessProbe(m)
+	m.delivery = NewPasteDelivery(m)
+	m.workerPool = NewPaneWorkerPool(m, m.config.Workers)
+	return m
+}
+
+// NewManagerFromConfig creates a manager for the session, Claude command and
+// workers of a resolved configuration (see config.Resolve)
+func NewManagerFromConfig(cfg *config.OrchestratorConfig) *Manager {
+	m := NewManager(cfg.Session.Name, cfg.ClaudeCmd())
+	m.config = cfg
+	m.workerPool = NewPaneWorkerPool(m, cfg.Workers)
+	return m
+}
+
+// SetMultiplexer replaces the tmux backend, e.g. with a FakeMultiplexer
+func (m *Manager) SetMultiplexer(mux Multiplexer) {
+	m.mux = mux
+}
+
+// Multiplexer returns the terminal multiplexer driving the session's panes
+func (m *Manager) Multiplexer() Multiplexer {
+	return m.mux
+}
+
+// Config returns the configuration the manager was created with
+func (m *Manager) Config() *config.OrchestratorConfig {
+	return m.config
 }
 
 func (m *Manager) SetMainTask(task string) {
@@ -53,56 +114,208 @@ func (m *Manager) IsOrchestratorMode() bool {
 	return m.orchestratorMode
 }
 
+// SetReadinessTimeout sets how long to wait for Claude to start in a pane
+func (m *Manager) SetReadinessTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		m.readiness.Timeout = timeout
+	}
+}
+
+// SetEventLogging controls whether orchestrator events are printed. It must
+// be called before InitializeOrchestrator.
+func (m *Manager) SetEventLogging(enabled bool) {
+	m.quietEvents = !enabled
+}
+
+// SetInterruptOnShutdown controls whether Shutdown interrupts busy workers.
+// Otherwise they keep working and a resumed plan waits for their reports.
+func (m *Manager) SetInterruptOnShutdown(enabled bool) {
+	m.interruptWorkers = enabled
+}
+
+// SetWorkersConfig replaces the worker pool with one using workersConfig.
+// It must be called before any worker is created.
+func (m *Manager) SetWorkersConfig(workersConfig config.WorkersConfig) {
+	m.config.Workers = workersConfig
+	m.workerPool = NewPaneWorkerPool(m, workersConfig)
+}
+
+// WorkerPool returns the pool of Claude worker panes
+func (m *Manager) WorkerPool() *PaneWorkerPool {
+	return m.workerPool
+}
+
+// SetStorageBackend selects where orchestrator state is persisted
+func (m *Manager) SetStorageBackend(backend string) error {
+	switch backend {
+	case StorageBackendFile, StorageBackendSQLite:
+		m.storageBackend = backend
+		return nil
+	default:
+		return fmt.Errorf("unknown storage backend: %s (expected %s or %s)", backend, StorageBackendFile, StorageBackendSQLite)
+	}
+}
+
 // InitializeOrchestrator initializes the orchestrator system
 func (m *Manager) InitializeOrchestrator(ctx context.Context) error {
 	if m.orchestrator != nil {
 		return nil // Already initialized
 	}
 
-	// Create event bus (mock implementation for now)
-	eventBus := &mockEventBus{}
+	if err := m.RegisterCompany(); err != nil {
+		return err
+	}
+
+	// Create in-process event bus
+	eventBus := orchestrator.NewInProcessEventBus(orchestrator.EventBusConfig{})
 
-	// Create storage (mock implementation for now)
-	storage := &mockStorage{}
+	// Create durable storage under the project directory
+	storage, err := m.newStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	// Initialize orchestrator (step manager, plan manager and parallel executor)
+	m.workerPool.SetStorage(storage)
+	orch := orchestrator.NewTaskOrchestrator(m.orchestratorConfig(), eventBus, storage, m.workerPool)
+	if err := orch.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start orchestrator: %w", err)
+	}
 
-	// Initialize step manager
-	stepConfig := orchestrator.StepManagerConfig{
-		MaxConcurrentSteps: 5,
-		StepTimeout:        30 * time.Minute,
-		ExecutorPoolSize:   3,
+	m.orchestrator = orch
+	m.eventBus = eventBus
+	m.stepManager = orch.StepManager()
+	m.workerPool.SetRequeueFunc(m.stepManager.RequeueStep)
+	m.taskPlanManager = orch.TaskPlanManager()
+	m.taskQueue = orch.Queue()
+
+	if !m.quietEvents {
+		events, err := eventBus.Subscribe(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to events: %w", err)
+		}
+		go m.logEvents(events)
+	}
+
+	fmt.Println("✅ Orchestrator system initialized")
+	return nil
+}
+
+// orchestratorConfig derives the orchestrator settings from the manager's
+// configuration. Up to one step per worker runs at a time.
+func (m *Manager) orchestratorConfig() orchestrator.OrchestratorConfig {
+	return orchestrator.OrchestratorConfig{
+		MaxConcurrentTasks: m.config.Workers.MaxWorkers,
+		TaskTimeout:        time.Duration(m.config.Workers.TaskTimeout) * time.Second,
 		RetryPolicy: orchestrator.RetryPolicy{
-			MaxRetries:     3,
+			MaxRetries:     m.config.Manager.MaxRetries,
 			InitialBackoff: 1 * time.Second,
 			MaxBackoff:     30 * time.Second,
 			BackoffFactor:  2.0,
 		},
 	}
-	m.stepManager = orchestrator.NewStepManager(eventBus, storage, stepConfig)
+}
 
-	// Initialize task plan manager
-	m.taskPlanManager = orchestrator.NewTaskPlanManager(eventBus, storage, m.stepManager)
+// OpenStorage opens the configured storage backend without starting the
+// orchestrator, e.g. to inspect persisted state
+func (m *Manager) OpenStorage() (orchestrator.Storage, error) {
+	return m.newStorage()
+}
 
-	fmt.Println("✅ Orchestrator system initialized")
-	return nil
+// newStorage creates the configured storage backend under DataDir
+func (m *Manager) newStorage() (orchestrator.Storage, error) {
+	if m.storageBackend == StorageBackendSQLite {
+		return orchestrator.NewSQLiteStorage(orchestrator.SQLiteStorageConfig{
+			Path: filepath.Join(m.DataDir(), "state.db"),
+		})
+	}
+	return orchestrator.NewFileStorage(orchestrator.FileStorageConfig{
+		BaseDir: m.DataDir(),
+	})
 }
 
-func (m *Manager) parseOutputLines(output []byte) []string {
-	lines := []string{}
-	current := ""
-	for _, b := range output {
-		if b == '\n' {
-			if current != "" {
-				lines = append(lines, current)
-				current = ""
-			}
-		} else {
-			current += string(b)
+// InboxDir returns the spool directory workers submit reports to
+func (m *Manager) InboxDir() string {
+	return filepath.Join(m.sessionDir(), "inbox")
+}
+
+// sessionDir holds per-session state shared between claude-company processes
+func (m *Manager) sessionDir() string {
+	return filepath.Join(m.DataDir(), "sessions", m.SessionName)
+}
+
+// sessionState is persisted so that other processes (e.g. status) know
+// which panes are parent panes
+type sessionState struct {
+	ParentPanes []string `json:"parent_panes"`
+}
+
+// LoadSessionState restores the parent panes recorded for the session
+func (m *Manager) LoadSessionState() error {
+	data, err := os.ReadFile(filepath.Join(m.sessionDir(), "session.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
 		}
+		return fmt.Errorf("failed to read session state: %w", err)
+	}
+
+	var state sessionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse session state: %w", err)
+	}
+	for _, pane := range state.ParentPanes {
+		m.ParentPanes[pane] = true
+	}
+	return nil
+}
+
+func (m *Manager) saveSessionState() error {
+	state := sessionState{ParentPanes: []string{}}
+	for pane := range m.ParentPanes {
+		state.ParentPanes = append(state.ParentPanes, pane)
+	}
+	sort.Strings(state.ParentPanes)
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
 	}
-	if current != "" {
-		lines = append(lines, current)
+	if err := os.MkdirAll(m.sessionDir(), 0755); err != nil {
+		return err
 	}
-	return lines
+	return os.WriteFile(filepath.Join(m.sessionDir(), "session.json"), data, 0644)
+}
+
+// ReportCommand returns the shell command a worker runs to report on a step
+func (m *Manager) ReportCommand(stepID string) string {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "claude-company"
+	}
+	return fmt.Sprintf("%s report --inbox %s --step %s --status completed --summary '<成果の要約>'",
+		shellQuote(exe), shellQuote(m.InboxDir()), shellQuote(stepID))
+}
+
+// EventBus returns the orchestrator event bus (nil before InitializeOrchestrator)
+func (m *Manager) EventBus() *orchestrator.InProcessEventBus {
+	return m.eventBus
+}
+
+// logEvents prints orchestrator events until the subscription ends
+func (m *Manager) logEvents(events <-chan orchestrator.TaskEvent) {
+	for event := range events {
+		fmt.Printf("📡 Event: %s for task %s\n", event.Type, event.TaskID)
+	}
+}
+
+// DataDir returns the directory where orchestrator state is persisted
+func (m *Manager) DataDir() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		wd = "."
+	}
+	return filepath.Join(wd, DataDirName)
 }
 
 func (m *Manager) BuildManagerPrompt(claudePane string) string {
@@ -113,6 +326,7 @@ ultrathink
 
 プロジェクトマネージャー(%s)として機能してください。
 
+%s
 ## 制限事項
 禁止: コード編集、ファイル操作、ビルド、テスト、デプロイ、技術実装
 許可: コード解析、タスク分析・分解、割り当て、進捗管理、品質管理、統合判定
@@ -129,9 +343,9 @@ ultrathink
 6. 統合テスト指示・完了判定
 
 ## ウィンドウ操作
-**重要**: 新ウィンドウのみに送信、親ペイン(%s)は管理専用なので'claude --dangerously-skip-permissions'の送信は不可
-**作成**: tmux new-window -t claude-squad
-**起動**: tmux send-keys -t 新ウィンドウ名 'claude --dangerously-skip-permissions' Enter
+**重要**: 新ウィンドウのみに送信、親ペイン(%s)は管理専用なので%sの送信は不可
+**作成**: tmux new-window -t %s
+**起動**: tmux send-keys -t 新ウィンドウ名 %s Enter
 **送信**: tmux send-keys -t 新ウィンドウ名 Enter
 
 サブタスクを作成するときの起動、1秒後に送信することは必須とする
@@ -166,14 +380,33 @@ ultrathink
 
 メインタスクの分析とサブタスク委託を開始してください。`,
 		claudePane,
+		m.managerGuidance(),
 		m.mainTask,
 		claudePane,
+		shellQuote(m.ClaudeCmd),
+		m.SessionName,
+		shellQuote(m.ClaudeCmd),
 		claudePane,
 		claudePane,
 		claudePane,
 		claudePane)
 }
 
+// managerGuidance renders the configured manager role and prompt, and the
+// language the manager should work in
+func (m *Manager) managerGuidance() string {
+	manager := m.config.Manager
+	var b strings.Builder
+	fmt.Fprintf(&b, "## 役割: %s\n", manager.Role)
+	if manager.Prompt != "" {
+		b.WriteString(manager.Prompt + "\n")
+	}
+	if language := m.config.Defaults.Language; language != "" {
+		fmt.Fprintf(&b, "\n## 言語\nサブタスクの指示・レビュー・報告は言語コード %s の言語で記述してください\n", language)
+	}
+	return b.String()
+}
+
 // BuildOrchestratorPrompt builds the orchestrator-specific prompt
 func (m *Manager) BuildOrchestratorPrompt(claudePane string) string {
 	_, _ = m.GetPanes()
@@ -183,6 +416,7 @@ ultrathink
 
 AIタスクオーケストレーター(%s)として機能してください。
 
+%s
 ## 制限事項
 禁止: コード編集、ファイル操作、ビルド、テスト、デプロイ、技術実装
 許可: タスク分析、計画立案、ステップベース実行管理、進捗監視、品質管理
@@ -204,8 +438,8 @@ AIタスクオーケストレーター(%s)として機能してください。
 - **Hybrid**: 依存関係を考慮した最適化実行
 
 ## ウィンドウ操作
-**作成**: tmux new-window -t claude-squad
-**起動**: tmux send-keys -t 新ウィンドウ名 'claude --dangerously-skip-permissions' Enter
+**作成**: tmux new-window -t %s
+**起動**: tmux send-keys -t 新ウィンドウ名 %s Enter
 **送信**: tmux send-keys -t 新ウィンドウ名 Enter
 ※送信は起動の1秒後に実行することを必須とする
 
@@ -263,7 +497,10 @@ AIタスクオーケストレーター(%s)として機能してください。
 
 メインタスクの分析とステップベース実行計画の立案を開始してください。`,
 		claudePane,
+		m.managerGuidance(),
 		m.mainTask,
+		m.SessionName,
+		shellQuote(m.ClaudeCmd),
 		claudePane,
 		claudePane,
 		claudePane,
@@ -275,8 +512,11 @@ AIタスクオーケストレーター(%s)として機能してください。
 }
 
 func (m *Manager) Setup() error {
-	if _, err := exec.LookPath("tmux"); err != nil {
-		return fmt.Errorf("❌ Error: tmux is not installed")
+	if err := m.mux.Check(); err != nil {
+		return fmt.Errorf("❌ Error: %v", err)
+	}
+	if err := m.RegisterCompany(); err != nil {
+		return err
 	}
 
 	// 初期状態のペインを記録
@@ -289,14 +529,14 @@ func (m *Manager) Setup() error {
 		return fmt.Errorf("failed to record initial windows: %v", err)
 	}
 
-	cmd := exec.Command("tmux", "has-session", "-t", m.SessionName)
-	if cmd.Run() == nil {
+	if m.mux.HasSession(m.SessionName) {
 		fmt.Printf("🔄 Session '%s' already exists.\n", m.SessionName)
 
 		fmt.Println("📊 Current pane status:")
-		statusCmd := exec.Command("tmux", "list-panes", "-s", "-t", m.SessionName, "-F", "#{pane_index}: #{pane_id} #{pane_current_command}")
-		if output, err := statusCmd.Output(); err == nil {
-			fmt.Print(string(output))
+		if panes, err := m.mux.ListPanes(m.SessionName); err == nil {
+			for _, pane := range panes {
+				fmt.Printf("%d: %s %s\n", pane.Index, pane.ID, pane.Command)
+			}
 		}
 
 		return m.attach()
@@ -329,39 +569,31 @@ func (m *Manager) Setup() error {
 }
 
 func (m *Manager) createSession() error {
-	cmd := exec.Command("tmux", "new-session", "-d", "-s", m.SessionName, "-n", "main")
-	return cmd.Run()
+	return m.mux.NewSession(m.SessionName, "main")
 }
 
 func (m *Manager) setupPanes() error {
-	commands := [][]string{
-		{"tmux", "split-window", "-v", "-t", m.SessionName + ":0.0"},
+	target := m.SessionName + ":0.0"
+	if _, err := m.mux.Split(target); err != nil {
+		return fmt.Errorf("failed to split %s: %w", target, err)
 	}
 
-	for _, cmdArgs := range commands {
-		cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to execute %v: %w", cmdArgs, err)
-		}
-	}
-
-	return nil
+	return m.arrangeWindow(target)
 }
 
 func (m *Manager) startClaudeSessions() error {
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", m.SessionName, "-F", "#{pane_id}")
-	output, err := cmd.Output()
+	panes, err := m.GetPanes()
 	if err != nil {
 		return err
 	}
 
-	lines := m.parseOutputLines(output)
-
-	if len(lines) > 1 {
-		bottomPaneID := lines[1]
+	if len(panes) > 1 {
+		bottomPaneID := panes[1]
 		fmt.Printf("🤖 Starting Claude Code in bottom pane %s...\n", bottomPaneID)
-		cmd := exec.Command("tmux", "send-keys", "-t", bottomPaneID, m.ClaudeCmd, "Enter")
-		if err := cmd.Run(); err != nil {
+		if err := m.mux.SetPaneTitle(bottomPaneID, workerLabel(m.config.Session.PanePrefix, "manager", "")); err != nil {
+			return err
+		}
+		if err := m.mux.SendKeys(bottomPaneID, m.ClaudeCmd, "Enter"); err != nil {
 			return fmt.Errorf("failed to start Claude in pane %s: %w", bottomPaneID, err)
 		}
 	}
@@ -370,50 +602,42 @@ func (m *Manager) startClaudeSessions() error {
 }
 
 func (m *Manager) setupMainPane() error {
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", m.SessionName, "-F", "#{pane_id}")
-	output, err := cmd.Output()
+	panes, err := m.GetPanes()
 	if err != nil {
 		return err
 	}
 
-	lines := m.parseOutputLines(output)
-
-	if len(lines) == 0 {
+	if len(panes) == 0 {
 		return fmt.Errorf("no panes found")
 	}
 
-	mainPaneID := lines[0]
+	mainPaneID := panes[0]
 
 	fmt.Println("📝 Setting up main pane with management commands...")
 
-	selectCmd := exec.Command("tmux", "select-pane", "-t", mainPaneID)
-	if err := selectCmd.Run(); err != nil {
+	if err := m.mux.SelectPane(mainPaneID); err != nil {
+		return err
+	}
+	if err := m.mux.SetPaneTitle(mainPaneID, workerLabel(m.config.Session.PanePrefix, "main", "")); err != nil {
 		return err
 	}
 
-	helpCmd := exec.Command("tmux", "send-keys", "-t", mainPaneID, "echo '🚀 Claude Company Manager - Use deploy command to assign AI tasks'", "Enter")
-	return helpCmd.Run()
+	return m.mux.SendKeys(mainPaneID, "echo '🚀 Claude Company Manager - Use deploy command to assign AI tasks'", "Enter")
 }
 
 func (m *Manager) attach() error {
 	if os.Getenv("TMUX") != "" {
 		fmt.Printf("🔄 Switching to session '%s'...\n", m.SessionName)
-		cmd := exec.Command("tmux", "switch-client", "-t", m.SessionName)
-		return cmd.Run()
 	} else {
 		fmt.Printf("🔗 Attaching to session '%s'...\n", m.SessionName)
-		cmd := exec.Command("tmux", "attach-session", "-t", m.SessionName)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
 	}
+	return m.mux.Attach(m.SessionName)
 }
 
+// SendToPane pastes a prompt into a pane and submits it (see PasteDelivery)
 func (m *Manager) SendToPane(paneID, command string) error {
-	cmd := exec.Command("tmux", "send-keys", "-t", paneID, command, "Enter")
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("tmux command failed: %v, output: %s", err, string(output))
+	if err := m.delivery.Send(context.Background(), paneID, command); err != nil {
+		return err
 	}
 
 	fmt.Printf("Task assigned to pane %s\n", paneID)
@@ -422,9 +646,8 @@ func (m *Manager) SendToPane(paneID, command string) error {
 
 // SendToWindow sends a command to a specific window
 func (m *Manager) SendToWindow(windowID, command string) error {
-	cmd := exec.Command("tmux", "send-keys", "-t", windowID, command, "Enter")
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("tmux command failed: %v, output: %s", err, string(output))
+	if err := m.delivery.Send(context.Background(), windowID, command); err != nil {
+		return err
 	}
 
 	fmt.Printf("Task assigned to window %s\n", windowID)
@@ -432,22 +655,28 @@ func (m *Manager) SendToWindow(windowID, command string) error {
 }
 
 func (m *Manager) SendToNewPaneOnly(command string) error {
+	_, err := m.sendToNewPane(command)
+	return err
+}
+
+// sendToNewPane creates a pane, starts Claude in it and sends the command,
+// returning the new pane ID
+func (m *Manager) sendToNewPane(command string) (string, error) {
 	newPaneID, err := m.CreateNewPaneAndGetID()
 	if err != nil {
-		return fmt.Errorf("failed to create new pane: %v", err)
+		return "", fmt.Errorf("failed to create new pane: %v", err)
 	}
 
 	if err := m.StartClaudeInNewPane(newPaneID); err != nil {
-		return fmt.Errorf("failed to start Claude in new pane: %v", err)
+		return "", fmt.Errorf("failed to start Claude in new pane: %v", err)
 	}
 
-	cmd := exec.Command("tmux", "send-keys", "-t", newPaneID, command, "Enter")
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("tmux command failed: %v, output: %s", err, string(output))
+	if err := m.delivery.Send(context.Background(), newPaneID, command); err != nil {
+		return "", err
 	}
 
 	fmt.Printf("📤 Task assigned to new pane %s only\n", newPaneID)
-	return nil
+	return newPaneID, nil
 }
 
 // SendToNewWindowOnly creates a new window and sends a command to it
@@ -461,9 +690,8 @@ func (m *Manager) SendToNewWindowOnly(command string) error {
 		return fmt.Errorf("failed to start Claude in new window: %v", err)
 	}
 
-	cmd := exec.Command("tmux", "send-keys", "-t", newWindowID, command, "Enter")
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("tmux command failed: %v, output: %s", err, string(output))
+	if err := m.delivery.Send(context.Background(), newWindowID, command); err != nil {
+		return err
 	}
 
 	fmt.Printf("📤 Task assigned to new window %s only\n", newWindowID)
@@ -471,119 +699,143 @@ func (m *Manager) SendToNewWindowOnly(command string) error {
 }
 
 func (m *Manager) GetPanes() ([]string, error) {
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", m.SessionName, "-F", "#{pane_id}")
-	output, err := cmd.Output()
+	panes, err := m.mux.ListPanes(m.SessionName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get panes: %v", err)
 	}
 
-	return m.parseOutputLines(output), nil
+	return paneIDs(panes), nil
 }
 
-func (m *Manager) GetAllPanes() ([]string, error) {
-	cmd := exec.Command("tmux", "list-panes", "-a", "-F", "#{pane_id}")
-	output, err := cmd.Output()
+// PaneInfo describes one pane of the session
+type PaneInfo struct {
+	ID      string `json:"id"`
+	Window  string `json:"window"`
+	Index   int    `json:"index"`
+	Command string `json:"command"`
+	Title   string `json:"title"`
+	Dead    bool   `json:"dead"`
+}
+
+// ListPaneDetails returns every pane of the session with its current command
+func (m *Manager) ListPaneDetails() ([]PaneInfo, error) {
+	panes, err := m.mux.ListPanes(m.SessionName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all panes: %v", err)
+		return nil, fmt.Errorf("failed to get panes: %v", err)
 	}
-
-	return strings.Fields(strings.TrimSpace(string(output))), nil
+	return panes, nil
 }
 
-func (m *Manager) CreateNewPaneAndGetID() (string, error) {
-	beforePanes, err := m.GetAllPanes()
+func (m *Manager) GetAllPanes() ([]string, error) {
+	panes, err := m.mux.ListPanes("")
 	if err != nil {
-		return "", fmt.Errorf("failed to get panes before creation: %v", err)
+		return nil, fmt.Errorf("failed to get all panes: %v", err)
 	}
 
-	cmd := exec.Command("tmux", "split-window", "-v", "-t", m.SessionName+":0.0")
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to create new pane: %v", err)
+	return paneIDs(panes), nil
+}
+
+func paneIDs(panes []PaneInfo) []string {
+	ids := make([]string, 0, len(panes))
+	for _, pane := range panes {
+		ids = append(ids, pane.ID)
 	}
+	return ids
+}
 
-	time.Sleep(500 * time.Millisecond)
+// CreateNewPaneAndGetID opens a pane for a worker as the layout dictates and
+// returns its ID. Creation is serialized so that each split sees the window
+// rearranged by the previous one.
+func (m *Manager) CreateNewPaneAndGetID() (string, error) {
+	m.createMu.Lock()
+	defer m.createMu.Unlock()
 
-	afterPanes, err := m.GetAllPanes()
+	paneID, err := m.openWorkerPane()
 	if err != nil {
-		return "", fmt.Errorf("failed to get panes after creation: %v", err)
+		return "", fmt.Errorf("failed to create new pane: %v", err)
 	}
 
-	for _, afterPane := range afterPanes {
-		found := false
-		for _, beforePane := range beforePanes {
-			if afterPane == beforePane {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return afterPane, nil
-		}
+	if err := m.arrangeWindow(paneID); err != nil {
+		fmt.Printf("⚠️  Failed to arrange the window of pane %s: %v\n", paneID, err)
 	}
-
-	return "", fmt.Errorf("failed to identify new pane ID")
+	return paneID, nil
 }
 
 func (m *Manager) StartClaudeInNewPane(paneID string) error {
+	return m.StartClaudeInPane(context.Background(), paneID)
+}
+
+// StartClaudeInPane starts Claude in a pane and waits until it is ready or
+// ctx is done
+func (m *Manager) StartClaudeInPane(ctx context.Context, paneID string) error {
+	if err := m.checkClaudeBinary(); err != nil {
+		return err
+	}
+
 	fmt.Printf("🤖 Starting Claude Code in new pane %s...\n", paneID)
-	cmd := exec.Command("tmux", "send-keys", "-t", paneID, m.ClaudeCmd, "Enter")
-	if err := cmd.Run(); err != nil {
+	if err := m.mux.SendKeys(paneID, m.ClaudeCmd, "Enter"); err != nil {
 		return fmt.Errorf("failed to start Claude in pane %s: %w", paneID, err)
 	}
 
-	for i := 0; i < 10; i++ {
-		time.Sleep(1 * time.Second)
-		if m.isClaudeReady(paneID) {
-			fmt.Printf("✅ Claude is ready in pane %s\n", paneID)
-			return nil
-		}
-		fmt.Printf("⏳ Waiting for Claude to start in pane %s... (%d/10)\n", paneID, i+1)
+	fmt.Printf("⏳ Waiting for Claude to start in pane %s...\n", paneID)
+	if err := m.readiness.Wait(ctx, paneID); err != nil {
+		return err
 	}
 
-	return fmt.Errorf("Claude failed to start within timeout in pane %s", paneID)
+	fmt.Printf("✅ Claude is ready in pane %s\n", paneID)
+	return nil
 }
 
 // StartClaudeInNewWindow starts Claude in a specific window
 func (m *Manager) StartClaudeInNewWindow(windowID string) error {
+	if err := m.checkClaudeBinary(); err != nil {
+		return err
+	}
+
 	fmt.Printf("🤖 Starting Claude Code in new window %s...\n", windowID)
-	cmd := exec.Command("tmux", "send-keys", "-t", windowID, m.ClaudeCmd, "Enter")
-	if err := cmd.Run(); err != nil {
+	if err := m.mux.SendKeys(windowID, m.ClaudeCmd, "Enter"); err != nil {
 		return fmt.Errorf("failed to start Claude in window %s: %w", windowID, err)
 	}
 
-	for i := 0; i < 10; i++ {
-		time.Sleep(1 * time.Second)
-		if m.isClaudeReadyInWindow(windowID) {
-			fmt.Printf("✅ Claude is ready in window %s\n", windowID)
-			return nil
-		}
-		fmt.Printf("⏳ Waiting for Claude to start in window %s... (%d/10)\n", windowID, i+1)
+	fmt.Printf("⏳ Waiting for Claude to start in window %s...\n", windowID)
+	if err := m.readiness.Wait(context.Background(), windowID); err != nil {
+		return err
 	}
 
-	return fmt.Errorf("Claude failed to start within timeout in window %s", windowID)
+	fmt.Printf("✅ Claude is ready in window %s\n", windowID)
+	return nil
 }
 
-func (m *Manager) isClaudeReady(paneID string) bool {
-	cmd := exec.Command("tmux", "capture-pane", "-t", paneID, "-p")
-	output, err := cmd.Output()
-	if err != nil {
-		return false
-	}
+// ReadinessProbe returns the probe used to wait for Claude to start
+func (m *Manager) ReadinessProbe() *ReadinessProbe {
+	return m.readiness
+}
 
-	content := string(output)
-	return strings.Contains(content, "claude") || strings.Contains(content, "ready") || strings.Contains(content, "$")
+// Delivery returns how prompts are pasted into panes
+func (m *Manager) Delivery() *PasteDelivery {
+	return m.delivery
 }
 
-// isClaudeReadyInWindow checks if Claude is ready in a specific window
-func (m *Manager) isClaudeReadyInWindow(windowID string) bool {
-	cmd := exec.Command("tmux", "capture-pane", "-t", windowID, "-p")
-	output, err := cmd.Output()
-	if err != nil {
-		return false
+// checkClaudeBinary fails early when the Claude command is not on PATH
+func (m *Manager) checkClaudeBinary() error {
+	fields := strings.Fields(m.ClaudeCmd)
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: claude command is empty", ErrClaudeNotFound)
 	}
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return fmt.Errorf("%w: %s", ErrClaudeNotFound, fields[0])
+	}
+	return nil
+}
 
-	content := string(output)
-	return strings.Contains(content, "claude") || strings.Contains(content, "ready") || strings.Contains(content, "$")
+// CapturePane returns the last lines of a pane's scrollback with wrapped
+// lines joined; lines <= 0 captures the whole history
+func (m *Manager) CapturePane(paneID string, lines int) (string, error) {
+	output, err := m.mux.Capture(paneID, lines)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture pane %s: %v", paneID, err)
+	}
+	return output, nil
 }
 
 // recordInitialPanes は初期状態のペインを記録し、親ペインとして設定
@@ -606,6 +858,17 @@ func (m *Manager) recordInitialPanes() error {
 	return nil
 }
 
+// MarkParentPanes は指定されたペインを親ペインとして登録（タスク送信対象外）
+// 他プロセス（status など）から参照できるようセッション状態にも保存する
+func (m *Manager) MarkParentPanes(paneIDs ...string) {
+	for _, pane := range paneIDs {
+		m.ParentPanes[pane] = true
+	}
+	if err := m.saveSessionState(); err != nil {
+		fmt.Printf("⚠️  Failed to save session state: %v\n", err)
+	}
+}
+
 // IsParentPane は指定されたペインが親ペインかどうかを判定
 func (m *Manager) IsParentPane(paneID string) bool {
 	return m.ParentPanes[paneID]
@@ -635,19 +898,33 @@ func (m *Manager) GetChildPanes() ([]string, error) {
 
 // SendToChildPaneOnly は子ペインにのみタスクを送信
 func (m *Manager) SendToChildPaneOnly(command string) error {
-	childPanes, err := m.GetChildPanes()
+	_, err := m.SendToChildPane(command)
+	return err
+}
+
+// SendToChildPane は空いている子ペインにタスクを送信し、送信先ペインIDを返す
+func (m *Manager) SendToChildPane(command string) (string, error) {
+	targetPane, err := m.idleChildPane(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to get child panes: %v", err)
+		return "", err
 	}
+	return targetPane, m.SendToPane(targetPane, command)
+}
 
-	if len(childPanes) == 0 {
-		// 子ペインが存在しない場合は新しく作成
-		return m.SendToNewPaneOnly(command)
+// idleChildPane は待機中のワーカーペインを返す。空きがなければ新しく作成する
+func (m *Manager) idleChildPane(ctx context.Context) (string, error) {
+	if err := m.workerPool.Sync(ctx); err != nil {
+		return "", fmt.Errorf("failed to get child panes: %v", err)
 	}
 
-	// 最初の子ペインに送信
-	targetPane := childPanes[0]
-	return m.SendToPane(targetPane, command)
+	worker, err := m.workerPool.FindAvailableWorker(ctx, orchestrator.WorkerRequirements{})
+	if err != nil {
+		worker, err = m.workerPool.CreateWorker(ctx, orchestrator.WorkerConfig{})
+		if err != nil {
+			return "", err
+		}
+	}
+	return worker.ID, nil
 }
 
 // SendToFilteredPane はペインフィルタリング付きでタスクを送信
@@ -676,53 +953,24 @@ func (m *Manager) CreateNewPaneAndRegisterAsChild() (string, error) {
 
 // CreateNewWindowAndGetID creates a new tmux window and returns its ID
 func (m *Manager) CreateNewWindowAndGetID() (string, error) {
-	// Get current windows before creation
-	beforeWindows, err := m.GetWindows()
-	if err != nil {
-		return "", fmt.Errorf("failed to get windows before creation: %v", err)
-	}
-
-	// Create new window in the session
-	cmd := exec.Command("tmux", "new-window", "-t", m.SessionName)
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to create new window: %v", err)
-	}
+	m.createMu.Lock()
+	defer m.createMu.Unlock()
 
-	// Wait for window creation
-	time.Sleep(500 * time.Millisecond)
-
-	// Get windows after creation
-	afterWindows, err := m.GetWindows()
+	windowID, _, err := m.mux.NewWindow(m.SessionName, m.config.Session.WindowPrefix)
 	if err != nil {
-		return "", fmt.Errorf("failed to get windows after creation: %v", err)
-	}
-
-	// Find the new window ID by comparing before and after
-	for _, afterWindow := range afterWindows {
-		found := false
-		for _, beforeWindow := range beforeWindows {
-			if afterWindow == beforeWindow {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return afterWindow, nil
-		}
+		return "", fmt.Errorf("failed to create new window: %v", err)
 	}
-
-	return "", fmt.Errorf("failed to identify new window ID")
+	return windowID, nil
 }
 
 // GetWindows returns a list of window IDs in the session
 func (m *Manager) GetWindows() ([]string, error) {
-	cmd := exec.Command("tmux", "list-windows", "-t", m.SessionName, "-F", "#{window_id}")
-	output, err := cmd.Output()
+	windows, err := m.mux.ListWindows(m.SessionName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get windows: %v", err)
 	}
 
-	return m.parseOutputLines(output), nil
+	return windows, nil
 }
 
 // recordInitialWindows records the initial state of windows
@@ -830,33 +1078,57 @@ func (m *Manager) CreatePlanForCurrentTask(ctx context.Context) (*orchestrator.T
 		return nil, fmt.Errorf("no current task available")
 	}
 
-	if m.taskPlanManager == nil {
-		return nil, fmt.Errorf("task plan manager not initialized")
-	}
-
-	plan := &orchestrator.TaskPlan{
-		TaskID:    m.currentTask.ID,
-		Strategy:  orchestrator.PlanStrategyHybrid,
-		Steps:     []orchestrator.TaskStep{},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	if m.orchestrator == nil {
+		return nil, fmt.Errorf("orchestrator not initialized")
 	}
 
-	err := m.taskPlanManager.CreatePlan(ctx, plan)
+	plan, err := m.orchestrator.CreatePlan(ctx, m.currentTask.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	m.currentTask.Plan = plan
 	return plan, nil
 }
 
 // ExecutePlan executes a task plan with step-based management
 func (m *Manager) ExecutePlan(ctx context.Context, planID string) error {
+	if m.orchestrator == nil {
+		return fmt.Errorf("orchestrator not initialized")
+	}
+
+	if m.stepExecutor != nil {
+		plan, err := m.taskPlanManager.GetPlan(ctx, planID)
+		if err != nil {
+			return err
+		}
+		if err := m.workerPool.Sync(ctx); err != nil {
+			return err
+		}
+		m.stepExecutor.PreparePlan(plan)
+		defer m.stepExecutor.Close()
+	}
+
+	return m.orchestrator.ExecutePlan(ctx, planID)
+}
+
+// AttachStepExecutor makes plan execution dispatch steps to Claude worker
+// panes; workers report back to reportPane
+func (m *Manager) AttachStepExecutor(reportPane string) (*StepExecutor, error) {
 	if m.taskPlanManager == nil {
-		return fmt.Errorf("task plan manager not initialized")
+		return nil, fmt.Errorf("orchestrator not initialized")
+	}
+
+	executor, err := NewStepExecutor(m, reportPane)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create step executor: %w", err)
 	}
+	executor.SetOutputCollector(NewOutputCollector(m, OutputCollectorConfig{}))
+	executor.SetAdaptivePlanner(orchestrator.NewAdaptivePlanner(nil))
 
-	return m.taskPlanManager.ExecutePlan(ctx, planID)
+	m.taskPlanManager.SetStepExecutor(executor.Execute)
+	m.stepExecutor = executor
+	return executor, nil
 }
 
 // SendTaskToPane sends an orchestrated task to a specific pane
@@ -879,29 +1151,14 @@ func (m *Manager) SendTaskToPane(ctx context.Context, paneID string, task *orche
 	return m.SendToPane(paneID, command)
 }
 
-// SendTaskToChildPane sends a task to any available child pane
+// SendTaskToChildPane sends a task to an idle child pane, starting a new
+// worker when none is idle
 func (m *Manager) SendTaskToChildPane(ctx context.Context, task *orchestrator.Task) error {
-	childPanes, err := m.GetChildPanes()
+	paneID, err := m.idleChildPane(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get child panes: %v", err)
-	}
-
-	if len(childPanes) == 0 {
-		// Create new pane if no child panes exist
-		newPaneID, err := m.CreateNewPaneAndRegisterAsChild()
-		if err != nil {
-			return fmt.Errorf("failed to create new pane: %v", err)
-		}
-
-		if err := m.StartClaudeInNewPane(newPaneID); err != nil {
-			return fmt.Errorf("failed to start Claude in new pane: %v", err)
-		}
-
-		return m.SendTaskToPane(ctx, newPaneID, task)
+		return err
 	}
-
-	// Use the first available child pane
-	return m.SendTaskToPane(ctx, childPanes[0], task)
+	return m.SendTaskToPane(ctx, paneID, task)
 }
 
 // buildOrchestratedTaskCommand builds a command string for orchestrated tasks
@@ -911,12 +1168,11 @@ func (m *Manager) buildOrchestratedTaskCommand(task *orchestrator.Task) string {
 成果物: タスク完了時の具体的成果物
 完了条件: %s
 実行戦略: Hybrid
-報告方法: tmux send-keys -t %%1 "実装完了: %s - %s" Enter; sleep 1; tmux send-keys -t %%1 "" Enter`,
+報告方法: %s`,
 		task.Title,
 		task.Description,
 		"実装とテストが完了していること",
-		task.Title,
-		"実装完了")
+		m.ReportCommand(task.ID))
 }
 
 // buildTraditionalTaskCommand builds a command string for traditional tasks
@@ -925,12 +1181,21 @@ func (m *Manager) buildTraditionalTaskCommand(task *orchestrator.Task) string {
 目的: %s
 成果物: タスク完了時の具体的成果物
 完了条件: %s
-報告方法: tmux send-keys -t %%1 "実装完了: %s - %s" Enter; sleep 1; tmux send-keys -t %%1 "" Enter`,
+報告方法: %s`,
 		task.Title,
 		task.Description,
 		"実装とテストが完了していること",
-		task.Title,
-		"実装完了")
+		m.ReportCommand(task.ID))
+}
+
+// shellQuote quotes s for safe use as a single POSIX shell word
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=%@", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
 }
 
 // GetPromptForMode returns the appropriate prompt based on the current mode
@@ -967,86 +1232,3 @@ func (m *Manager) GetModeStatus() string {
 	}
 	return "Traditional Manager Mode (Basic task delegation)"
 }
-
-// Mock implementations for orchestrator interfaces
-type mockEventBus struct{}
-
-func (m *mockEventBus) Publish(ctx context.Context, event orchestrator.TaskEvent) error {
-	fmt.Printf("📡 Event: %s for task %s\n", event.Type, event.TaskID)
-	return nil
-}
-
-func (m *mockEventBus) Subscribe(ctx context.Context, eventTypes []orchestrator.TaskEventType) (<-chan orchestrator.TaskEvent, error) {
-	ch := make(chan orchestrator.TaskEvent, 10)
-	return ch, nil
-}
-
-func (m *mockEventBus) Unsubscribe(ctx context.Context, subscription string) error {
-	return nil
-}
-
-func (m *mockEventBus) AddFilter(ctx context.Context, filter orchestrator.EventFilter) error {
-	return nil
-}
-
-func (m *mockEventBus) RemoveFilter(ctx context.Context, filterID string) error {
-	return nil
-}
-
-type mockStorage struct{}
-
-func (m *mockStorage) SaveTask(ctx context.Context, task *orchestrator.Task) error {
-	return nil
-}
-
-func (m *mockStorage) LoadTask(ctx context.Context, taskID string) (*orchestrator.Task, error) {
-	return nil, fmt.Errorf("task not found")
-}
-
-func (m *mockStorage) ListTasks(ctx context.Context, filter orchestrator.TaskFilter) ([]*orchestrator.Task, error) {
-	return []*orchestrator.Task{}, nil
-}
-
-func (m *mockStorage) DeleteTask(ctx context.Context, taskID string) error {
-	return nil
-}
-
-func (m *mockStorage) SavePlan(ctx context.Context, plan *orchestrator.TaskPlan) error {
-	return nil
-}
-
-func (m *mockStorage) LoadPlan(ctx context.Context, planID string) (*orchestrator.TaskPlan, error) {
-	return nil, fmt.Errorf("plan not found")
-}
-
-func (m *mockStorage) DeletePlan(ctx context.Context, planID string) error {
-	return nil
-}
-
-func (m *mockStorage) SaveWorker(ctx context.Context, worker *orchestrator.Worker) error {
-	return nil
-}
-
-func (m *mockStorage) LoadWorker(ctx context.Context, workerID string) (*orchestrator.Worker, error) {
-	return nil, fmt.Errorf("worker not found")
-}
-
-func (m *mockStorage) ListWorkers(ctx context.Context) ([]*orchestrator.Worker, error) {
-	return []*orchestrator.Worker{}, nil
-}
-
-func (m *mockStorage) DeleteWorker(ctx context.Context, workerID string) error {
-	return nil
-}
-
-func (m *mockStorage) SaveEvent(ctx context.Context, event *orchestrator.TaskEvent) error {
-	return nil
-}
-
-func (m *mockStorage) ListEvents(ctx context.Context, filter orchestrator.EventFilter) ([]*orchestrator.TaskEvent, error) {
-	return []*orchestrator.TaskEvent{}, nil
-}
-
-func (m *mockStorage) Cleanup(ctx context.Context) error {
-	return nil
-}
@@ -0,0 +1,122 @@
+package session
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakeManager returns a manager for session "test" driven by a fake that
+// starts Claude when "claude" is entered
+func newFakeManager(t *testing.T) (*Manager, *FakeMultiplexer) {
+	t.Helper()
+	t.Setenv(RegistryDirEnvVar, t.TempDir())
+
+	fake := NewFakeMultiplexer()
+	fake.EmulateClaude("claude")
+	m := NewManager("test", "claude")
+	m.SetMultiplexer(fake)
+	m.Delivery().Interval = 10 * time.Millisecond
+	return m, fake
+}
+
+// hasCommand reports whether the fake ran a command starting with prefix
+func hasCommand(fake *FakeMultiplexer, prefix ...string) bool {
+	for _, command := range fake.Commands() {
+		if len(command) >= len(prefix) && reflect.DeepEqual(command[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSetup(t *testing.T) {
+	m, fake := newFakeManager(t)
+
+	if err := m.Setup(); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	for _, prefix := range [][]string{
+		{"new-session", "-d", "-s", "test", "-n", "main"},
+		{"split-window", "-d", "-v"},
+		{"select-layout", "-t", "test:0.0", "tiled"},
+		{"attach-session", "-t", "test"},
+	} {
+		if !hasCommand(fake, prefix...) {
+			t.Errorf("command %v was not run; commands: %v", prefix, fake.Commands())
+		}
+	}
+
+	panes, err := fake.ListPanes("test")
+	if err != nil {
+		t.Fatalf("ListPanes: %v", err)
+	}
+	if len(panes) != 2 {
+		t.Fatalf("got %d panes, want 2", len(panes))
+	}
+	main, manager := panes[0], panes[1]
+
+	if got := fake.Sent(manager.ID); !reflect.DeepEqual(got, []string{"claude"}) {
+		t.Errorf("manager pane got %q, want Claude started", got)
+	}
+	if manager.Command != "claude" || manager.Title != "claude-manager" {
+		t.Errorf("manager pane = %+v, want claude titled claude-manager", manager)
+	}
+	if got := fake.Sent(main.ID); len(got) != 1 || !strings.HasPrefix(got[0], "echo ") {
+		t.Errorf("main pane got %q, want the welcome echo", got)
+	}
+	if fake.SelectedPane() != main.ID {
+		t.Errorf("selected pane = %s, want %s", fake.SelectedPane(), main.ID)
+	}
+
+	company, err := mustRegistry(t).Get("test")
+	if err != nil || company == nil {
+		t.Errorf("session not registered: %v", err)
+	}
+}
+
+func TestSendToPaneMultiline(t *testing.T) {
+	m, fake := newFakeManager(t)
+	if err := fake.NewSession("test", "main"); err != nil {
+		t.Fatal(err)
+	}
+
+	prompt := "first line\nsecond line with Enter and C-c\n'quoted' $HOME"
+	if err := m.SendToPane("%0", prompt); err != nil {
+		t.Fatalf("SendToPane: %v", err)
+	}
+
+	if got := fake.Sent("%0"); !reflect.DeepEqual(got, []string{prompt}) {
+		t.Errorf("pane got %q, want the prompt entered once", got)
+	}
+	if pending := fake.Pending("%0"); pending != "" {
+		t.Errorf("pending input %q left in the pane", pending)
+	}
+
+	// The text is pasted, and send-keys only presses Enter afterwards
+	var pasted bool
+	for _, command := range fake.Commands() {
+		switch command[0] {
+		case "paste-buffer":
+			pasted = true
+		case "send-keys":
+			if !pasted || !reflect.DeepEqual(command, []string{"send-keys", "-t", "%0", "Enter"}) {
+				t.Errorf("unexpected %v", command)
+			}
+		}
+	}
+	if !pasted {
+		t.Errorf("prompt was not pasted; commands: %v", fake.Commands())
+	}
+}
+
+func mustRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry, err := DefaultRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return registry
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"claude-company/internal/orchestrator"
+)
+
+// RunManifest creates every task of the manifest and then plans and executes
+// them one at a time in dependency order. Tasks whose dependencies did not
+// complete are cancelled instead of run. The results follow the execution
+// order.
+func (m *Manager) RunManifest(ctx context.Context, manifest *orchestrator.TaskManifest) ([]RunResult, error) {
+	if m.orchestrator == nil {
+		return nil, fmt.Errorf("orchestrator not initialized")
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	ordered := manifest.Order()
+
+	// Create all tasks up front so that the whole batch shows up in status
+	taskIDs := make(map[string]string, len(ordered))
+	for _, item := range ordered {
+		resp, err := m.orchestrator.CreateTask(ctx, item.Request())
+		if err != nil {
+			return nil, fmt.Errorf("task %s: %w", item.ID, err)
+		}
+		taskIDs[item.ID] = resp.TaskID
+		fmt.Printf("🆕 %s → タスク %s\n", item.ID, resp.TaskID)
+	}
+
+	results := make([]RunResult, 0, len(ordered))
+	succeeded := make(map[string]bool, len(ordered))
+	for _, item := range ordered {
+		var blocked, dependsOn []string
+		for _, dep := range item.DependsOn {
+			dependsOn = append(dependsOn, taskIDs[dep])
+			if !succeeded[dep] {
+				blocked = append(blocked, dep)
+			}
+		}
+
+		if len(blocked) > 0 || ctx.Err() != nil {
+			reason := fmt.Errorf("dependencies did not complete: %s", strings.Join(blocked, ", "))
+			if len(blocked) == 0 {
+				reason = ctx.Err()
+			}
+			m.orchestrator.CancelTask(ctx, taskIDs[item.ID])
+			task, _ := m.orchestrator.GetTask(ctx, taskIDs[item.ID])
+			fmt.Printf("⏭️  %s (タスク %s) をスキップしました: %v\n", item.ID, taskIDs[item.ID], reason)
+			results = append(results, RunResult{Task: task, Err: reason})
+			continue
+		}
+
+		task, err := m.runTask(ctx, taskIDs[item.ID], dependsOn)
+		if task == nil {
+			return results, err
+		}
+		succeeded[item.ID] = err == nil
+		results = append(results, RunResult{Task: task, Err: err})
+	}
+
+	return results, nil
+}
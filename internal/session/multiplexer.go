@@ -0,0 +1,302 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Multiplexer is the terminal multiplexer a Manager drives its panes with.
+// Targets are anything tmux accepts with -t: pane IDs, window IDs or
+// session:window.pane. TmuxMultiplexer is the real implementation and
+// FakeMultiplexer keeps panes in memory.
+type Multiplexer interface {
+	// Check fails when the multiplexer is not installed
+	Check() error
+	HasSession(name string) bool
+	// NewSession creates a detached session whose first window is windowName
+	NewSession(name, windowName string) error
+	// ListSessions lists the names of all sessions, none when no server runs
+	ListSessions() ([]string, error)
+	KillSession(name string) error
+	RenameSession(name, newName string) error
+	// NewWindow adds a window named name to the session and returns the IDs
+	// of the window and its pane
+	NewWindow(session, name string) (windowID, paneID string, err error)
+	// Split splits the target pane vertically without selecting the new pane
+	// and returns the new pane's ID
+	Split(target string) (string, error)
+	// SelectLayout arranges the panes of the target window with a preset
+	// layout such as tiled or main-vertical
+	SelectLayout(target, layout string) error
+	// SetPaneTitle labels the target pane; the label outlives the title the
+	// pane's program sets
+	SetPaneTitle(target, title string) error
+	RenameWindow(target, name string) error
+	SetWindowOption(target, option, value string) error
+	// ListPanes lists the panes of a session, or of every session when
+	// session is empty
+	ListPanes(session string) ([]PaneInfo, error)
+	ListWindows(session string) ([]string, error)
+	// DescribePane returns the pane the target resolves to, or nil when
+	// there is no such pane
+	DescribePane(target string) (*PaneInfo, error)
+	// SendKeys types the keys into the target; key names such as Enter and
+	// Escape are pressed rather than typed
+	SendKeys(target string, keys ...string) error
+	// Paste pastes text into the target through a paste buffer, as a
+	// bracketed paste when the target's program enabled it. Newlines are
+	// pasted as they are and nothing is read as a key name.
+	Paste(target, text string) error
+	// Capture returns the last lines of the target's scrollback with wrapped
+	// lines joined; lines <= 0 captures the whole history
+	Capture(target string, lines int) (string, error)
+	SelectPane(target string) error
+	KillPane(target string) error
+	// RespawnPane kills the target's process and starts a fresh shell
+	RespawnPane(target string) error
+	// Attach attaches the terminal to the session, or switches the client
+	// when already inside the multiplexer
+	Attach(session string) error
+}
+
+// paneTitleOption holds the label set by SetPaneTitle. Claude rewrites the
+// pane title with escape sequences, so the label is kept in a pane option.
+const paneTitleOption = "@claude_company_title"
+
+// paneTitleFormat expands to the pane label, or the pane title when unlabelled
+const paneTitleFormat = "#{?" + paneTitleOption + ",#{" + paneTitleOption + "},#{pane_title}}"
+
+// paneFormat is the list-panes format parsed by parsePaneInfo. tmux prints
+// tabs as "_" unless the client is UTF-8, so fields are separated by "|" and
+// the title, which may contain one, comes last.
+const paneFormat = "#{pane_id}|#{window_index}|#{pane_index}|#{pane_current_command}|#{pane_dead}|" + paneTitleFormat
+
+// TmuxMultiplexer runs the tmux command
+type TmuxMultiplexer struct{}
+
+var _ Multiplexer = (*TmuxMultiplexer)(nil)
+
+func NewTmuxMultiplexer() *TmuxMultiplexer {
+	return &TmuxMultiplexer{}
+}
+
+func (t *TmuxMultiplexer) Check() error {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return errors.New("tmux is not installed")
+	}
+	return nil
+}
+
+func (t *TmuxMultiplexer) HasSession(name string) bool {
+	return exec.Command("tmux", "has-session", "-t", name).Run() == nil
+}
+
+func (t *TmuxMultiplexer) NewSession(name, windowName string) error {
+	return exec.Command("tmux", "new-session", "-d", "-s", name, "-n", windowName).Run()
+}
+
+func (t *TmuxMultiplexer) ListSessions() ([]string, error) {
+	output, err := exec.Command("tmux", "list-sessions", "-F", "#{session_name}").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && (strings.Contains(string(exitErr.Stderr), "no server running") ||
+			strings.Contains(string(exitErr.Stderr), "error connecting to")) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+func (t *TmuxMultiplexer) KillSession(name string) error {
+	return exec.Command("tmux", "kill-session", "-t", name).Run()
+}
+
+func (t *TmuxMultiplexer) RenameSession(name, newName string) error {
+	return exec.Command("tmux", "rename-session", "-t", name, newName).Run()
+}
+
+func (t *TmuxMultiplexer) NewWindow(session, name string) (string, string, error) {
+	output, err := tmuxOutput("new-window", "-d", "-P", "-F", "#{window_id} #{pane_id}", "-t", session+":", "-n", name)
+	if err != nil {
+		return "", "", err
+	}
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("unexpected new-window output: %q", output)
+	}
+	return fields[0], fields[1], nil
+}
+
+func (t *TmuxMultiplexer) Split(target string) (string, error) {
+	output, err := tmuxOutput("split-window", "-d", "-v", "-P", "-F", "#{pane_id}", "-t", target)
+	if err != nil {
+		return "", err
+	}
+	if output == "" {
+		return "", fmt.Errorf("split-window printed no pane ID")
+	}
+	return output, nil
+}
+
+func (t *TmuxMultiplexer) SelectLayout(target, layout string) error {
+	return exec.Command("tmux", "select-layout", "-t", target, layout).Run()
+}
+
+func (t *TmuxMultiplexer) SetPaneTitle(target, title string) error {
+	if err := exec.Command("tmux", "select-pane", "-t", target, "-T", title).Run(); err != nil {
+		return err
+	}
+	return exec.Command("tmux", "set-option", "-p", "-t", target, paneTitleOption, title).Run()
+}
+
+func (t *TmuxMultiplexer) RenameWindow(target, name string) error {
+	return exec.Command("tmux", "rename-window", "-t", target, name).Run()
+}
+
+func (t *TmuxMultiplexer) SetWindowOption(target, option, value string) error {
+	return exec.Command("tmux", "set-option", "-w", "-t", target, option, value).Run()
+}
+
+func (t *TmuxMultiplexer) ListPanes(session string) ([]PaneInfo, error) {
+	args := []string{"list-panes", "-a", "-F", paneFormat}
+	if session != "" {
+		args = []string{"list-panes", "-s", "-t", session, "-F", paneFormat}
+	}
+	output, err := exec.Command("tmux", args...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var panes []PaneInfo
+	for _, line := range strings.Split(string(output), "\n") {
+		if pane, ok := parsePaneInfo(line); ok {
+			panes = append(panes, pane)
+		}
+	}
+	return panes, nil
+}
+
+func (t *TmuxMultiplexer) ListWindows(session string) ([]string, error) {
+	output, err := exec.Command("tmux", "list-windows", "-t", session, "-F", "#{window_id}").Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+func (t *TmuxMultiplexer) DescribePane(target string) (*PaneInfo, error) {
+	output, err := exec.Command("tmux", "display-message", "-p", "-t", target, paneFormat).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	// A missing target yields empty output rather than an error
+	pane, ok := parsePaneInfo(strings.TrimRight(string(output), "\n"))
+	if !ok {
+		return nil, nil
+	}
+	return &pane, nil
+}
+
+func (t *TmuxMultiplexer) SendKeys(target string, keys ...string) error {
+	args := append([]string{"send-keys", "-t", target}, keys...)
+	if output, err := exec.Command("tmux", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux command failed: %v, output: %s", err, string(output))
+	}
+	return nil
+}
+
+// pasteBuffers numbers the paste buffers, so that concurrent pastes never
+// share one
+var pasteBuffers atomic.Int64
+
+func (t *TmuxMultiplexer) Paste(target, text string) error {
+	buffer := fmt.Sprintf("claude-company-%d-%d", os.Getpid(), pasteBuffers.Add(1))
+
+	load := exec.Command("tmux", "load-buffer", "-b", buffer, "-")
+	load.Stdin = strings.NewReader(text)
+	if output, err := load.CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux load-buffer failed: %v, output: %s", err, string(output))
+	}
+
+	// -d deletes the buffer once pasted, -p brackets the paste and -r keeps
+	// newlines rather than turning them into carriage returns
+	if output, err := exec.Command("tmux", "paste-buffer", "-d", "-p", "-r", "-b", buffer, "-t", target).CombinedOutput(); err != nil {
+		exec.Command("tmux", "delete-buffer", "-b", buffer).Run()
+		return fmt.Errorf("tmux paste-buffer failed: %v, output: %s", err, string(output))
+	}
+	return nil
+}
+
+func (t *TmuxMultiplexer) Capture(target string, lines int) (string, error) {
+	start := "-"
+	if lines > 0 {
+		start = fmt.Sprintf("-%d", lines)
+	}
+	output, err := exec.Command("tmux", "capture-pane", "-t", target, "-p", "-J", "-S", start).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+func (t *TmuxMultiplexer) SelectPane(target string) error {
+	return exec.Command("tmux", "select-pane", "-t", target).Run()
+}
+
+func (t *TmuxMultiplexer) KillPane(target string) error {
+	return exec.Command("tmux", "kill-pane", "-t", target).Run()
+}
+
+func (t *TmuxMultiplexer) RespawnPane(target string) error {
+	return exec.Command("tmux", "respawn-pane", "-k", "-t", target).Run()
+}
+
+func (t *TmuxMultiplexer) Attach(session string) error {
+	if os.Getenv("TMUX") != "" {
+		return exec.Command("tmux", "switch-client", "-t", session).Run()
+	}
+
+	cmd := exec.Command("tmux", "attach-session", "-t", session)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// tmuxOutput runs tmux and returns its trimmed output. Failures carry tmux's
+// message, e.g. "no space for new pane".
+func tmuxOutput(args ...string) (string, error) {
+	output, err := exec.Command("tmux", args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%v: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// parsePaneInfo parses one line printed with paneFormat
+func parsePaneInfo(line string) (PaneInfo, bool) {
+	fields := strings.SplitN(line, "|", 6)
+	if len(fields) < 6 || fields[0] == "" {
+		return PaneInfo{}, false
+	}
+	index, _ := strconv.Atoi(fields[2])
+	return PaneInfo{
+		ID:      fields[0],
+		Window:  fields[1],
+		Index:   index,
+		Command: fields[3],
+		Dead:    fields[4] == "1",
+		Title:   fields[5],
+	}, true
+}
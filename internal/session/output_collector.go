@@ -0,0 +1,214 @@
+package session
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutputCollector keeps a rolling transcript per pane by periodically
+// capturing the full scrollback and appending only lines not seen before.
+type OutputCollector struct {
+	mu          sync.Mutex
+	manager     *Manager
+	config      OutputCollectorConfig
+	transcripts map[string]*transcript
+}
+
+// OutputCollectorConfig configures an OutputCollector
+type OutputCollectorConfig struct {
+	Interval time.Duration
+	MaxLines int // transcript lines kept per pane
+	// OverlapLines is how many trailing lines are matched to find where a
+	// new capture continues the transcript
+	OverlapLines int
+}
+
+type transcript struct {
+	lines   []string
+	dropped int // lines trimmed from the front, so marks stay valid
+	last    []string
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// NewOutputCollector creates a collector reading panes through manager
+func NewOutputCollector(manager *Manager, config OutputCollectorConfig) *OutputCollector {
+	if config.Interval <= 0 {
+		config.Interval = 2 * time.Second
+	}
+	if config.MaxLines <= 0 {
+		config.MaxLines = 5000
+	}
+	if config.OverlapLines <= 0 {
+		config.OverlapLines = 8
+	}
+
+	return &OutputCollector{
+		manager:     manager,
+		config:      config,
+		transcripts: make(map[string]*transcript),
+	}
+}
+
+// Start begins collecting the pane until ctx is done or Stop is called.
+// Calling Start for a pane that is already collected is a no-op.
+func (c *OutputCollector) Start(ctx context.Context, paneID string) {
+	c.mu.Lock()
+	t := c.transcript(paneID)
+	if t.ctx != nil && t.ctx.Err() == nil {
+		c.mu.Unlock()
+		return
+	}
+	collectCtx, cancel := context.WithCancel(ctx)
+	t.ctx, t.cancel = collectCtx, cancel
+	c.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(c.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			c.Collect(paneID)
+			select {
+			case <-collectCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops collecting the pane; the transcript is kept
+func (c *OutputCollector) Stop(paneID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t, exists := c.transcripts[paneID]; exists && t.cancel != nil {
+		t.cancel()
+	}
+}
+
+// StopAll stops collecting every pane
+func (c *OutputCollector) StopAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, t := range c.transcripts {
+		if t.cancel != nil {
+			t.cancel()
+		}
+	}
+}
+
+// Collect captures the pane once and appends new lines to its transcript
+func (c *OutputCollector) Collect(paneID string) error {
+	content, err := c.manager.CapturePane(paneID, 0)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimRight(content, "\n "), "\n")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := c.transcript(paneID)
+	t.append(newLines(t.last, lines, c.config.OverlapLines))
+	t.last = lines
+
+	if excess := len(t.lines) - c.config.MaxLines; excess > 0 {
+		t.lines = append([]string(nil), t.lines[excess:]...)
+		t.dropped += excess
+	}
+	return nil
+}
+
+// Mark returns a position in the pane transcript for use with Since
+func (c *OutputCollector) Mark(paneID string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := c.transcript(paneID)
+	return t.dropped + len(t.lines)
+}
+
+// Since returns the transcript collected after mark
+func (c *OutputCollector) Since(paneID string, mark int) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := c.transcript(paneID)
+	start := mark - t.dropped
+	if start < 0 {
+		start = 0
+	}
+	if start > len(t.lines) {
+		return ""
+	}
+	return strings.Join(t.lines[start:], "\n")
+}
+
+// Transcript returns the whole rolling transcript of the pane
+func (c *OutputCollector) Transcript(paneID string) string {
+	return c.Since(paneID, 0)
+}
+
+// transcript must be called with c.mu held
+func (c *OutputCollector) transcript(paneID string) *transcript {
+	t, exists := c.transcripts[paneID]
+	if !exists {
+		t = &transcript{}
+		c.transcripts[paneID] = t
+	}
+	return t
+}
+
+func (t *transcript) append(lines []string) {
+	t.lines = append(t.lines, lines...)
+}
+
+// newLines returns the part of current that follows previous. The last
+// overlap lines of previous are located in current; if they cannot be found
+// (cleared screen, trimmed history) the whole capture is treated as new.
+func newLines(previous, current []string, overlap int) []string {
+	if len(previous) == 0 {
+		return current
+	}
+
+	// The last line may still be changing (prompt, spinner), so it is
+	// excluded from matching and re-read on the next capture
+	anchor := previous[:len(previous)-1]
+	if len(anchor) == 0 {
+		return current
+	}
+	if len(anchor) > overlap {
+		anchor = anchor[len(anchor)-overlap:]
+	}
+
+	for start := len(current) - len(anchor); start >= 0; start-- {
+		if equalLines(current[start:start+len(anchor)], anchor) {
+			next := start + len(anchor)
+			// Skip the line that was incomplete in the previous capture
+			// when it is unchanged
+			if next < len(current) && current[next] == previous[len(previous)-1] {
+				next++
+			}
+			return current[next:]
+		}
+	}
+
+	return current
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
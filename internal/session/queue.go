@@ -0,0 +1,112 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"claude-company/internal/orchestrator"
+)
+
+// DrainOptions controls how DrainQueue takes tasks from the queue
+type DrainOptions struct {
+	// Watch keeps polling for new tasks once the queue is empty
+	Watch bool
+	// PollInterval is how often an empty queue is checked in watch mode
+	PollInterval time.Duration
+}
+
+// RunResult is the outcome of a task run by DrainQueue or RunManifest
+type RunResult struct {
+	Task *orchestrator.Task
+	Err  error
+}
+
+// DrainQueue plans and executes queued tasks one at a time, taking the next
+// one as soon as the current plan finishes. A failed task does not stop the
+// queue. It returns the results when the queue is empty, or keeps waiting
+// for tasks until ctx is done in watch mode.
+func (m *Manager) DrainQueue(ctx context.Context, opts DrainOptions) ([]RunResult, error) {
+	if m.taskQueue == nil {
+		return nil, fmt.Errorf("orchestrator not initialized")
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+
+	var results []RunResult
+	for {
+		next, err := m.taskQueue.Next(ctx)
+		if err != nil {
+			return results, fmt.Errorf("failed to read task queue: %w", err)
+		}
+
+		if next == nil {
+			if !opts.Watch {
+				return results, nil
+			}
+			select {
+			case <-ctx.Done():
+				return results, nil
+			case <-time.After(opts.PollInterval):
+			}
+			continue
+		}
+
+		task, err := m.runTask(ctx, next.ID, nil)
+		if task == nil {
+			return results, err
+		}
+		results = append(results, RunResult{Task: task, Err: err})
+
+		if ctx.Err() != nil {
+			return results, nil
+		}
+	}
+}
+
+// runTask plans and executes a task; dependsOn lists the tasks its plan
+// depends on. The returned task is nil when it could not be loaded.
+func (m *Manager) runTask(ctx context.Context, taskID string, dependsOn []string) (*orchestrator.Task, error) {
+	task, err := m.orchestrator.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.SetMainTask(task.Description)
+	m.currentTask = task
+	fmt.Printf("📥 タスク %s を開始します [%s] %s\n", task.ID, task.Priority, task.Title)
+
+	plan, err := m.CreatePlanForCurrentTask(ctx)
+	if err != nil {
+		// Take the task out of the queue so that it is not picked again
+		failed := orchestrator.TaskStatusFailed
+		m.orchestrator.UpdateTask(ctx, task.ID, orchestrator.TaskUpdate{Status: &failed})
+		fmt.Printf("❌ タスク %s のプラン作成に失敗しました: %v\n", task.ID, err)
+		return task, fmt.Errorf("failed to create plan: %w", err)
+	}
+
+	if len(dependsOn) > 0 {
+		if err := m.orchestrator.UpdatePlan(ctx, plan.ID, orchestrator.PlanUpdate{Dependencies: dependsOn}); err != nil {
+			return task, err
+		}
+	}
+
+	fmt.Printf("🗂️  タスク %s のプラン %s を作成しました (%s, %dステップ)\n", task.ID, plan.ID, plan.Strategy, len(plan.Steps))
+	for _, step := range plan.Steps {
+		fmt.Printf("   %d. %s\n", step.Order, step.Name)
+	}
+
+	if err := m.ExecutePlan(ctx, plan.ID); err != nil {
+		if task.Status == orchestrator.TaskStatusPending {
+			// The plan never started; do not pick the task again
+			failed := orchestrator.TaskStatusFailed
+			m.orchestrator.UpdateTask(ctx, task.ID, orchestrator.TaskUpdate{Status: &failed})
+		}
+		fmt.Printf("❌ タスク %s が失敗しました: %v\n", task.ID, err)
+		return task, err
+	}
+
+	fmt.Printf("✅ タスク %s の全ステップが完了しました\n", task.ID)
+	return task, nil
+}
@@ -0,0 +1,223 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Readiness errors reported by ReadinessProbe.Wait
+var (
+	ErrClaudeNotFound = errors.New("claude binary not found")
+	ErrAuthRequired   = errors.New("claude is showing an authentication prompt")
+	ErrReadyTimeout   = errors.New("timed out waiting for claude to become ready")
+	ErrPaneDead       = errors.New("pane process exited")
+)
+
+// PaneSnapshot is one observation of a pane taken by the readiness probe
+type PaneSnapshot struct {
+	PaneID         string
+	Content        string
+	CurrentCommand string
+	Dead           bool
+	Time           time.Time
+}
+
+// ReadinessDetector judges one aspect of whether Claude is ready to accept
+// input. Check returns an error to abort the probe (e.g. auth prompt shown).
+type ReadinessDetector interface {
+	Name() string
+	Check(current *PaneSnapshot, history []*PaneSnapshot) (bool, error)
+}
+
+// ReadinessProbe polls a pane until every detector reports ready
+type ReadinessProbe struct {
+	manager   *Manager
+	detectors []ReadinessDetector
+	Timeout   time.Duration
+	Interval  time.Duration
+}
+
+// NewReadinessProbe creates a probe with the default detectors
+func NewReadinessProbe(manager *Manager) *ReadinessProbe {
+	return &ReadinessProbe{
+		manager: manager,
+		detectors: []ReadinessDetector{
+			&FailureDetector{},
+			&ProcessDetector{},
+			&PromptBoxDetector{},
+			&StableOutputDetector{},
+		},
+		Timeout:  60 * time.Second,
+		Interval: 500 * time.Millisecond,
+	}
+}
+
+// SetDetectors replaces the detectors used by the probe
+func (p *ReadinessProbe) SetDetectors(detectors ...ReadinessDetector) {
+	p.detectors = detectors
+}
+
+// Wait blocks until Claude in the target pane (or window) is ready
+func (p *ReadinessProbe) Wait(ctx context.Context, target string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	var history []*PaneSnapshot
+	var pending []string
+	for {
+		snapshot, err := p.snapshot(target)
+		if err != nil {
+			return err
+		}
+		if snapshot.Dead {
+			return fmt.Errorf("%w: %s", ErrPaneDead, target)
+		}
+
+		pending = pending[:0]
+		for _, detector := range p.detectors {
+			ready, err := detector.Check(snapshot, history)
+			if err != nil {
+				return fmt.Errorf("pane %s: %w", target, err)
+			}
+			if !ready {
+				pending = append(pending, detector.Name())
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		history = append(history, snapshot)
+		if len(history) > 20 {
+			history = history[1:]
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("%w after %s in pane %s (waiting on: %s)", ErrReadyTimeout, p.Timeout, target, strings.Join(pending, ", "))
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ReadinessProbe) snapshot(target string) (*PaneSnapshot, error) {
+	pane, err := p.manager.mux.DescribePane(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect pane %s: %v", target, err)
+	}
+
+	snapshot := &PaneSnapshot{PaneID: target, Time: time.Now()}
+	if pane == nil {
+		snapshot.Dead = true
+		return snapshot, nil
+	}
+	snapshot.Dead = pane.Dead
+	snapshot.CurrentCommand = pane.Command
+
+	content, err := p.manager.CapturePane(target, 200)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Content = content
+	return snapshot, nil
+}
+
+// FailureDetector aborts the probe when the pane shows that Claude could not
+// start or is waiting for login
+type FailureDetector struct{}
+
+var (
+	notFoundPattern = regexp.MustCompile(`(?i)claude: (command )?not found|command not found: claude|no such file or directory.*claude`)
+	authPattern     = regexp.MustCompile(`(?i)select login method|paste code here|log in to your anthropic|invalid api key|please run /login`)
+)
+
+func (d *FailureDetector) Name() string { return "failure" }
+
+func (d *FailureDetector) Check(current *PaneSnapshot, history []*PaneSnapshot) (bool, error) {
+	if notFoundPattern.MatchString(current.Content) {
+		return false, ErrClaudeNotFound
+	}
+	if authPattern.MatchString(current.Content) {
+		return false, ErrAuthRequired
+	}
+	return true, nil
+}
+
+// claudeCommands are the foreground commands of a pane running Claude
+var claudeCommands = []string{"claude", "node"}
+
+// ProcessDetector is ready when the pane's foreground process is Claude
+// rather than the shell
+type ProcessDetector struct {
+	Commands []string // defaults to claudeCommands
+}
+
+func (d *ProcessDetector) Name() string { return "process" }
+
+func (d *ProcessDetector) Check(current *PaneSnapshot, history []*PaneSnapshot) (bool, error) {
+	commands := d.Commands
+	if len(commands) == 0 {
+		commands = claudeCommands
+	}
+	return containsString(commands, current.CurrentCommand), nil
+}
+
+// PromptBoxDetector is ready when Claude's input box is drawn
+type PromptBoxDetector struct {
+	Pattern *regexp.Regexp
+}
+
+var defaultPromptBoxPattern = regexp.MustCompile(`(?m)^\s*│\s*>|\? for shortcuts`)
+
+func (d *PromptBoxDetector) Name() string { return "prompt_box" }
+
+func (d *PromptBoxDetector) Check(current *PaneSnapshot, history []*PaneSnapshot) (bool, error) {
+	pattern := d.Pattern
+	if pattern == nil {
+		pattern = defaultPromptBoxPattern
+	}
+	return pattern.MatchString(current.Content), nil
+}
+
+// StableOutputDetector is ready once the pane content has stopped changing
+// for Duration, i.e. Claude finished drawing its start-up screen
+type StableOutputDetector struct {
+	Duration time.Duration
+}
+
+func (d *StableOutputDetector) Name() string { return "stable_output" }
+
+func (d *StableOutputDetector) Check(current *PaneSnapshot, history []*PaneSnapshot) (bool, error) {
+	duration := d.Duration
+	if duration <= 0 {
+		duration = 1500 * time.Millisecond
+	}
+
+	stableSince := current.Time
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Content != current.Content {
+			break
+		}
+		stableSince = history[i].Time
+	}
+	return current.Time.Sub(stableSince) >= duration, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
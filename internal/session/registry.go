@@ -0,0 +1,185 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RegistryDirEnvVar overrides where the registry of companies is kept
+const RegistryDirEnvVar = "CLAUDE_COMPANY_REGISTRY"
+
+// ErrSessionInUse is returned when a live session of the same name belongs to
+// another project
+var ErrSessionInUse = errors.New("session is used by another project")
+
+// Company is a claude-company session registered on this machine
+type Company struct {
+	Session    string    `json:"session"`
+	ProjectDir string    `json:"project_dir"`
+	ConfigFile string    `json:"config_file,omitempty"`
+	Layout     string    `json:"layout"`
+	StartedAt  time.Time `json:"started_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Registry keeps one file per company, so that processes of different
+// projects never rewrite each other's entries
+type Registry struct {
+	dir string
+}
+
+// NewRegistry opens the registry kept in dir
+func NewRegistry(dir string) *Registry {
+	return &Registry{dir: dir}
+}
+
+// DefaultRegistry opens $CLAUDE_COMPANY_REGISTRY, or claude-company/companies
+// under the user configuration directory
+func DefaultRegistry() (*Registry, error) {
+	if dir := os.Getenv(RegistryDirEnvVar); dir != "" {
+		return NewRegistry(dir), nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate the registry: %w", err)
+	}
+	return NewRegistry(filepath.Join(configDir, "claude-company", "companies")), nil
+}
+
+// Get returns the company registered for the session, or nil
+func (r *Registry) Get(session string) (*Company, error) {
+	data, err := os.ReadFile(r.path(session))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var company Company
+	if err := json.Unmarshal(data, &company); err != nil {
+		return nil, fmt.Errorf("failed to read registry entry %s: %w", session, err)
+	}
+	return &company, nil
+}
+
+// Register adds or replaces the entry of company.Session
+func (r *Registry) Register(company Company) error {
+	data, err := json.MarshalIndent(company, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(r.dir, ".company-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), r.path(company.Session)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// Unregister removes the entry of the session, if any
+func (r *Registry) Unregister(session string) error {
+	if err := os.Remove(r.path(session)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List returns every registered company ordered by session name
+func (r *Registry) List() ([]Company, error) {
+	entries, err := os.ReadDir(r.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var companies []Company
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		session, err := url.PathUnescape(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+		company, err := r.Get(session)
+		if err != nil || company == nil {
+			continue
+		}
+		companies = append(companies, *company)
+	}
+
+	sort.Slice(companies, func(i, j int) bool {
+		return companies[i].Session < companies[j].Session
+	})
+	return companies, nil
+}
+
+func (r *Registry) path(session string) string {
+	return filepath.Join(r.dir, url.PathEscape(session)+".json")
+}
+
+// RegisterCompany records the session in the registry of companies on this
+// machine. It fails with ErrSessionInUse when a live session of the same name
+// was registered by another project; other registry failures only warn.
+func (m *Manager) RegisterCompany() error {
+	registry, err := DefaultRegistry()
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		return nil
+	}
+
+	projectDir := filepath.Dir(m.DataDir())
+	existing, err := registry.Get(m.SessionName)
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		existing = nil
+	}
+
+	now := time.Now()
+	company := Company{
+		Session:    m.SessionName,
+		ProjectDir: projectDir,
+		ConfigFile: m.config.Source,
+		Layout:     m.layout(),
+		StartedAt:  now,
+		UpdatedAt:  now,
+	}
+	if existing != nil && m.mux.HasSession(m.SessionName) {
+		if existing.ProjectDir != projectDir {
+			return fmt.Errorf("%w: '%s' belongs to %s (choose another name with --session)", ErrSessionInUse, m.SessionName, existing.ProjectDir)
+		}
+		company.StartedAt = existing.StartedAt
+	}
+
+	if err := registry.Register(company); err != nil {
+		fmt.Printf("⚠️  Failed to register session '%s': %v\n", m.SessionName, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"claude-company/internal/orchestrator"
+)
+
+// ResumePlan continues a plan whose process died. Step statuses are reloaded
+// from storage and reconciled with the panes of the session: completed steps
+// are skipped, steps still running on a surviving worker pane are waited on,
+// and the remaining steps run again from the first unfinished ready step.
+func (m *Manager) ResumePlan(ctx context.Context, planID string) error {
+	if m.taskPlanManager == nil {
+		return fmt.Errorf("orchestrator not initialized")
+	}
+
+	running, err := m.taskPlanManager.ResumePlan(ctx, planID)
+	if err != nil {
+		return err
+	}
+	plan, err := m.taskPlanManager.GetPlan(ctx, planID)
+	if err != nil {
+		return err
+	}
+	task, err := m.orchestrator.GetTask(ctx, plan.TaskID)
+	if err != nil {
+		return err
+	}
+	m.SetMainTask(task.Description)
+	m.currentTask = task
+	task.Plan = plan
+
+	adopted, err := m.workerPool.Restore(ctx, running)
+	if err != nil {
+		return err
+	}
+
+	done := 0
+	for _, step := range plan.Steps {
+		if step.Status == orchestrator.TaskStatusCompleted || step.Status == orchestrator.TaskStatusCancelled {
+			done++
+		}
+	}
+	fmt.Printf("🔄 タスク %s のプラン %s を再開します (%d/%dステップ完了済み)\n", task.ID, plan.ID, done, len(plan.Steps))
+	for _, worker := range adopted {
+		fmt.Printf("🔗 %s (pane %s) はステップ %s を実行中です\n", worker.Name, worker.ID, *worker.CurrentTask)
+	}
+
+	return m.ExecutePlan(ctx, planID)
+}
@@ -2,9 +2,6 @@ package session
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
-	"strings"
 )
 
 type SessionManager interface {
@@ -19,124 +16,86 @@ type SessionManager interface {
 	GetPanes(sessionName string) ([]string, error)
 }
 
-type TmuxSessionManager struct{}
+// TmuxSessionManager manages whole sessions through a Multiplexer
+type TmuxSessionManager struct {
+	mux Multiplexer
+}
 
 func NewTmuxSessionManager() *TmuxSessionManager {
-	return &TmuxSessionManager{}
+	return NewSessionManagerWith(NewTmuxMultiplexer())
+}
+
+// NewSessionManagerWith creates a session manager driving mux, e.g. a
+// FakeMultiplexer
+func NewSessionManagerWith(mux Multiplexer) *TmuxSessionManager {
+	return &TmuxSessionManager{mux: mux}
 }
 
 func (t *TmuxSessionManager) CreateSession(name string) error {
 	if t.SessionExists(name) {
 		return fmt.Errorf("session '%s' already exists", name)
 	}
-	
-	cmd := exec.Command("tmux", "new-session", "-d", "-s", name)
-	return cmd.Run()
+
+	return t.mux.NewSession(name, "main")
 }
 
 func (t *TmuxSessionManager) AttachSession(name string) error {
 	if !t.SessionExists(name) {
 		return fmt.Errorf("session '%s' does not exist", name)
 	}
-	
-	var cmd *exec.Cmd
-	if os.Getenv("TMUX") != "" {
-		cmd = exec.Command("tmux", "switch-client", "-t", name)
-	} else {
-		cmd = exec.Command("tmux", "attach-session", "-t", name)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-	}
-	
-	return cmd.Run()
+
+	return t.mux.Attach(name)
 }
 
 func (t *TmuxSessionManager) KillSession(name string) error {
 	if !t.SessionExists(name) {
 		return fmt.Errorf("session '%s' does not exist", name)
 	}
-	
-	cmd := exec.Command("tmux", "kill-session", "-t", name)
-	return cmd.Run()
+
+	return t.mux.KillSession(name)
 }
 
 func (t *TmuxSessionManager) RenameSession(oldName, newName string) error {
 	if !t.SessionExists(oldName) {
 		return fmt.Errorf("session '%s' does not exist", oldName)
 	}
-	
+
 	if t.SessionExists(newName) {
 		return fmt.Errorf("session '%s' already exists", newName)
 	}
-	
-	cmd := exec.Command("tmux", "rename-session", "-t", oldName, newName)
-	return cmd.Run()
+
+	return t.mux.RenameSession(oldName, newName)
 }
 
+// SwitchSession switches the client to the session, attaching the terminal
+// when it is not inside the multiplexer
 func (t *TmuxSessionManager) SwitchSession(name string) error {
-	if !t.SessionExists(name) {
-		return fmt.Errorf("session '%s' does not exist", name)
-	}
-	
-	cmd := exec.Command("tmux", "switch-client", "-t", name)
-	return cmd.Run()
+	return t.AttachSession(name)
 }
 
 func (t *TmuxSessionManager) ListSessions() ([]string, error) {
-	cmd := exec.Command("tmux", "list-sessions")
-	output, err := cmd.Output()
+	sessions, err := t.mux.ListSessions()
 	if err != nil {
-		if strings.Contains(err.Error(), "no server running") {
-			return []string{}, nil
-		}
 		return nil, err
 	}
-	
-	if len(output) == 0 {
+	if sessions == nil {
 		return []string{}, nil
 	}
-	
-	sessions := strings.Split(strings.TrimSpace(string(output)), "\n")
 	return sessions, nil
 }
 
 func (t *TmuxSessionManager) SessionExists(name string) bool {
-	cmd := exec.Command("tmux", "has-session", "-t", name)
-	err := cmd.Run()
-	return err == nil
+	return t.mux.HasSession(name)
 }
 
 func (t *TmuxSessionManager) SendKeysToPane(paneID, command string) error {
-	cmd := exec.Command("tmux", "send-keys", "-t", paneID, command, "Enter")
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("tmux command failed: %v, output: %s", err, string(output))
-	}
-	return nil
+	return t.mux.SendKeys(paneID, command, "Enter")
 }
 
 func (t *TmuxSessionManager) GetPanes(sessionName string) ([]string, error) {
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", sessionName, "-F", "#{pane_id}")
-	output, err := cmd.Output()
+	panes, err := t.mux.ListPanes(sessionName)
 	if err != nil {
 		return nil, err
 	}
-
-	lines := []string{}
-	current := ""
-	for _, b := range output {
-		if b == '\n' {
-			if current != "" {
-				lines = append(lines, current)
-				current = ""
-			}
-		} else {
-			current += string(b)
-		}
-	}
-	if current != "" {
-		lines = append(lines, current)
-	}
-
-	return lines, nil
-}
\ No newline at end of file
+	return paneIDs(panes), nil
+}
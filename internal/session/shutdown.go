@@ -0,0 +1,67 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"claude-company/internal/orchestrator"
+)
+
+// ShutdownSummary describes what was left unfinished by Shutdown
+type ShutdownSummary struct {
+	Task *orchestrator.Task
+	Plan *orchestrator.TaskPlan
+	// Unfinished are the plan steps that were neither completed nor cancelled
+	Unfinished []orchestrator.TaskStep
+	// Running are busy workers left working; a resumed plan waits for them
+	Running []*orchestrator.Worker
+	// Interrupted are busy workers that were interrupted and released
+	Interrupted []*orchestrator.Worker
+}
+
+// Shutdown stops dispatching, waits for the running steps to wind down and
+// flushes events and plan state to storage. Busy workers are interrupted when
+// SetInterruptOnShutdown was enabled. The plan can be continued with resume.
+func (m *Manager) Shutdown(ctx context.Context) (*ShutdownSummary, error) {
+	summary := &ShutdownSummary{}
+	if m.orchestrator == nil {
+		return summary, nil
+	}
+
+	stopErr := m.orchestrator.Stop(ctx)
+
+	for _, worker := range m.workerPool.Workers() {
+		if worker.CurrentTask == nil {
+			continue
+		}
+		if !m.interruptWorkers {
+			summary.Running = append(summary.Running, worker)
+			continue
+		}
+		if err := m.workerPool.Interrupt(worker.ID); err != nil {
+			fmt.Printf("⚠️  %v\n", err)
+			summary.Running = append(summary.Running, worker)
+			continue
+		}
+		m.workerPool.Release(ctx, worker.ID, *worker.CurrentTask)
+		summary.Interrupted = append(summary.Interrupted, worker)
+	}
+
+	if m.eventBus != nil {
+		m.eventBus.Close()
+	}
+
+	if m.currentTask != nil {
+		summary.Task = m.currentTask
+		if plan, err := m.taskPlanManager.GetPlanByTask(ctx, m.currentTask.ID); err == nil {
+			summary.Plan = plan
+			for _, step := range plan.Steps {
+				if step.Status != orchestrator.TaskStatusCompleted && step.Status != orchestrator.TaskStatusCancelled {
+					summary.Unfinished = append(summary.Unfinished, step)
+				}
+			}
+		}
+	}
+
+	return summary, stopErr
+}
@@ -0,0 +1,72 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"claude-company/internal/orchestrator"
+)
+
+// PlanSteps returns the steps of a plan with their current state
+func (m *Manager) PlanSteps(ctx context.Context, planID string) ([]orchestrator.TaskStep, error) {
+	if m.taskPlanManager == nil {
+		return nil, fmt.Errorf("orchestrator not initialized")
+	}
+	return m.taskPlanManager.PlanSteps(ctx, planID)
+}
+
+// IsPlanExecuting reports whether the plan is being executed by this process
+func (m *Manager) IsPlanExecuting(planID string) bool {
+	return m.taskPlanManager != nil && m.taskPlanManager.IsExecuting(planID)
+}
+
+// CancelStep cancels a step of the plan and interrupts the worker running it
+func (m *Manager) CancelStep(ctx context.Context, planID, stepID string) error {
+	if m.taskPlanManager == nil {
+		return fmt.Errorf("orchestrator not initialized")
+	}
+
+	worker := m.workerPool.WorkerForTask(stepID)
+	if err := m.taskPlanManager.CancelStep(ctx, planID, stepID); err != nil {
+		return err
+	}
+	if worker != nil {
+		return m.workerPool.Interrupt(worker.ID)
+	}
+	return nil
+}
+
+// RetryStep runs a step again. A running step is interrupted and sent to a
+// worker again; a failed or cancelled step is reset to pending. When the
+// plan is no longer executing, the caller executes it again to run the step.
+func (m *Manager) RetryStep(ctx context.Context, planID, stepID string) error {
+	if m.taskPlanManager == nil {
+		return fmt.Errorf("orchestrator not initialized")
+	}
+
+	if worker := m.workerPool.WorkerForTask(stepID); worker != nil {
+		if err := m.workerPool.Interrupt(worker.ID); err != nil {
+			return err
+		}
+	}
+	return m.taskPlanManager.RetryStep(ctx, planID, stepID)
+}
+
+// ReassignStep moves a running step to another worker. The current worker is
+// interrupted and the step is restarted on a different one.
+func (m *Manager) ReassignStep(ctx context.Context, stepID string) error {
+	if m.stepManager == nil {
+		return fmt.Errorf("orchestrator not initialized")
+	}
+
+	worker := m.workerPool.WorkerForTask(stepID)
+	if worker == nil {
+		return fmt.Errorf("step %s is not running on a worker", stepID)
+	}
+
+	m.workerPool.Avoid(stepID, worker.ID)
+	if err := m.workerPool.Interrupt(worker.ID); err != nil {
+		return err
+	}
+	return m.stepManager.RestartStep(ctx, stepID, "reassigned from "+worker.Name)
+}
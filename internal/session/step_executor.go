@@ -0,0 +1,250 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/prompts"
+	"claude-company/internal/report"
+)
+
+// ReportWaiter delivers worker completion reports to the step executor
+type ReportWaiter interface {
+	// Mark is called before the step is dispatched so that only reports
+	// arriving afterwards are returned by Wait
+	Mark(stepID string) error
+	Wait(ctx context.Context, stepID string) (*report.Report, error)
+}
+
+// StepExecutor dispatches plan steps to idle Claude worker panes and blocks
+// until the worker reports back. Steps may run concurrently, one per worker.
+type StepExecutor struct {
+	manager    *Manager
+	templates  *prompts.StepTemplates
+	waiter     ReportWaiter
+	reportPane string
+	collector  *OutputCollector
+	planner    *orchestrator.AdaptivePlanner
+}
+
+// NewStepExecutor creates a step executor. Workers report through the
+// session report inbox; reportPane is where legacy send-keys reports go.
+func NewStepExecutor(manager *Manager, reportPane string) (*StepExecutor, error) {
+	waiter, err := NewInboxReportWaiter(manager.InboxDir())
+	if err != nil {
+		return nil, err
+	}
+
+	return &StepExecutor{
+		manager:    manager,
+		templates:  prompts.NewStepTemplates(),
+		waiter:     waiter,
+		reportPane: reportPane,
+	}, nil
+}
+
+// SetReportWaiter replaces how completion reports are received
+func (e *StepExecutor) SetReportWaiter(waiter ReportWaiter) {
+	e.waiter = waiter
+}
+
+// SetOutputCollector enables transcript collection of worker panes
+func (e *StepExecutor) SetOutputCollector(collector *OutputCollector) {
+	e.collector = collector
+}
+
+// SetAdaptivePlanner makes completed steps be evaluated on their transcript.
+// The transcript is only available when an output collector is set.
+func (e *StepExecutor) SetAdaptivePlanner(planner *orchestrator.AdaptivePlanner) {
+	e.planner = planner
+}
+
+// PreparePlan registers the plan about to be executed with the adaptive planner
+func (e *StepExecutor) PreparePlan(plan *orchestrator.TaskPlan) {
+	if e.planner != nil {
+		e.planner.SetPlan(orchestrator.PlanFromTaskPlan(plan))
+	}
+}
+
+// Close stops collecting worker output
+func (e *StepExecutor) Close() {
+	if e.collector != nil {
+		e.collector.StopAll()
+	}
+}
+
+// Transcript returns the collected output of a worker pane
+func (e *StepExecutor) Transcript(paneID string) string {
+	if e.collector == nil {
+		return ""
+	}
+	return e.collector.Transcript(paneID)
+}
+
+// Execute renders the step prompt, sends it to a child pane and waits for the
+// report. It matches orchestrator.StepExecutorFunc.
+func (e *StepExecutor) Execute(ctx context.Context, step *orchestrator.TaskStep) (*orchestrator.StepOutput, error) {
+	prompt, err := e.BuildPrompt(step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build prompt for step %s: %w", step.ID, err)
+	}
+
+	pool := e.manager.WorkerPool()
+	startTime := time.Now()
+
+	// A worker restored by a resumed plan may still be working on the step;
+	// its report is awaited instead of dispatching the step again
+	worker := pool.WorkerForTask(step.ID)
+	if worker != nil {
+		fmt.Printf("🔗 Step %s (%s) is still running on %s; waiting for its report\n", step.ID, step.Name, worker.Name)
+		defer e.release(ctx, worker.ID, step.ID)
+	} else {
+		if err := e.waiter.Mark(step.ID); err != nil {
+			return nil, fmt.Errorf("failed to prepare report for step %s: %w", step.ID, err)
+		}
+
+		worker, err = pool.Acquire(ctx, orchestrator.WorkerRequirements{Capabilities: stepCapabilities(step.Type)}, step.ID)
+		if err != nil {
+			return nil, fmt.Errorf("no worker for step %s: %w", step.ID, err)
+		}
+		defer e.release(ctx, worker.ID, step.ID)
+
+		fmt.Printf("📤 Dispatching step %s (%s) to %s\n", step.ID, step.Name, worker.Name)
+		if err := e.manager.SendToPane(worker.ID, prompt); err != nil {
+			return nil, fmt.Errorf("failed to dispatch step %s: %w", step.ID, err)
+		}
+	}
+	paneID := worker.ID
+
+	mark := 0
+	if e.collector != nil {
+		e.collector.Collect(paneID)
+		mark = e.collector.Mark(paneID)
+		e.collector.Start(context.Background(), paneID)
+	}
+
+	rep, err := e.waiter.Wait(ctx, step.ID)
+	if err != nil {
+		return nil, fmt.Errorf("no report for step %s: %w", step.ID, err)
+	}
+	if rep.Pane == "" {
+		rep.Pane = paneID
+	}
+
+	fmt.Printf("📥 Report received for step %s: %s\n", step.ID, rep.Status)
+	if rep.Failed() {
+		return nil, &orchestrator.StepFailedError{StepError: rep.StepError()}
+	}
+
+	output := rep.StepOutput()
+	if e.collector != nil {
+		e.collector.Collect(paneID)
+		transcript := e.collector.Since(paneID, mark)
+		if data, ok := output.Data.(map[string]any); ok {
+			data["transcript_lines"] = strings.Count(transcript, "\n") + 1
+			if evaluation := e.evaluate(step.ID, transcript, startTime); evaluation != nil {
+				data["evaluation"] = evaluation
+			}
+		}
+	}
+
+	return output, nil
+}
+
+// release makes the worker idle after the step. On shutdown the worker keeps
+// the step: it may still finish it, and a resumed plan waits for its report.
+func (e *StepExecutor) release(ctx context.Context, workerID, stepID string) {
+	if errors.Is(context.Cause(ctx), orchestrator.ErrShutdown) {
+		return
+	}
+	e.manager.WorkerPool().Release(context.Background(), workerID, stepID)
+}
+
+// evaluate runs the adaptive planner on the worker transcript of a step
+func (e *StepExecutor) evaluate(stepID, transcript string, startTime time.Time) map[string]any {
+	if e.planner == nil {
+		return nil
+	}
+
+	result, err := e.planner.ExecuteStep(stepID, transcript, startTime, time.Now())
+	if err != nil {
+		return nil
+	}
+
+	return map[string]any{
+		"status":           result.Status.String(),
+		"quality":          result.Quality.String(),
+		"completion_rate":  result.CompletionRate,
+		"efficiency_score": result.EfficiencyScore,
+		"warnings":         result.Warnings,
+		"feedback":         result.Feedback,
+	}
+}
+
+// BuildPrompt renders the step with the template matching its type
+func (e *StepExecutor) BuildPrompt(step *orchestrator.TaskStep) (string, error) {
+	data := prompts.StepData{
+		StepName:           step.Name,
+		StepDescription:    step.Description,
+		Purpose:            step.Description,
+		Deliverables:       []string{step.Name + "の成果物"},
+		CompletionCriteria: []string{"作業内容が目的を満たしていること", "完了後に下記の報告方法で結果を報告すること"},
+		ReportPane:         e.reportPane,
+		ReportMessage:      fmt.Sprintf("%s 完了: <成果の要約>", reportMarker(step.ID)),
+		Dependencies:       step.Dependencies,
+		Context:            fmt.Sprintf("ステップID: %s\n失敗した場合は報告メッセージの「完了」を「失敗」に置き換え、理由を記載してください。", step.ID),
+	}
+	if _, ok := e.waiter.(*InboxReportWaiter); ok {
+		data.ReportCommand = e.manager.ReportCommand(step.ID)
+		data.Context = fmt.Sprintf("ステップID: %s\n失敗した場合は --status failed を指定し、--summary に理由を記載してください。", step.ID)
+	}
+
+	return e.templates.BuildStepPrompt(stepTemplateName(step.Type), data)
+}
+
+func stepTemplateName(stepType orchestrator.StepType) string {
+	switch stepType {
+	case orchestrator.StepTypeImplementation:
+		return "code_implementation"
+	case orchestrator.StepTypeTesting:
+		return "testing"
+	case orchestrator.StepTypeDocumentation:
+		return "documentation"
+	case orchestrator.StepTypeResearch:
+		return "research"
+	case orchestrator.StepTypeReview:
+		return "review"
+	default:
+		return "step_execution"
+	}
+}
+
+func reportMarker(stepID string) string {
+	return "[" + stepID + "]"
+}
+
+// InboxReportWaiter receives reports submitted with `claude-company report`
+type InboxReportWaiter struct {
+	inbox *report.Inbox
+}
+
+// NewInboxReportWaiter opens the report inbox at dir
+func NewInboxReportWaiter(dir string) (*InboxReportWaiter, error) {
+	inbox, err := report.NewInbox(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &InboxReportWaiter{inbox: inbox}, nil
+}
+
+func (w *InboxReportWaiter) Mark(stepID string) error {
+	return w.inbox.Discard(stepID)
+}
+
+func (w *InboxReportWaiter) Wait(ctx context.Context, stepID string) (*report.Report, error) {
+	return w.inbox.Receive(ctx, stepID)
+}
@@ -0,0 +1,793 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"claude-company/internal/config"
+	"claude-company/internal/orchestrator"
+)
+
+// PaneWorkerPool implements orchestrator.WorkerManager over tmux child panes.
+// Each child pane running Claude is one worker, identified by its pane ID.
+type PaneWorkerPool struct {
+	mu       sync.Mutex
+	manager  *Manager
+	config   config.WorkersConfig
+	storage  orchestrator.Storage
+	workers  map[string]*orchestrator.Worker
+	order    []string // pane IDs in registration order
+	nextRole int
+	// pollInterval is how often Acquire re-checks for a free worker
+	pollInterval time.Duration
+	health       WorkerHealthConfig
+	activity     map[string]*paneActivity
+	// ranClaude holds the panes Claude is known to have run in; only these
+	// are respawned when lost, a user's own pane is never killed
+	ranClaude map[string]bool
+	requeue   RequeueFunc
+	// avoid maps a task to the worker its next Acquire should not pick
+	avoid map[string]string
+}
+
+// WorkerHealthConfig configures MonitorWorkers
+type WorkerHealthConfig struct {
+	Interval time.Duration // how often workers are checked
+	// StaleTimeout is how long a busy worker's pane may show no new output
+	// before the worker is considered hung
+	StaleTimeout time.Duration
+}
+
+// RequeueFunc hands the in-flight step of a lost worker back for retry
+type RequeueFunc func(ctx context.Context, stepID string, reason string) error
+
+// Health check failures
+var (
+	ErrWorkerDead = errors.New("worker is dead")
+	ErrWorkerHung = errors.New("worker is hung")
+)
+
+// shellCommands are foreground commands meaning Claude has exited to the shell
+var shellCommands = []string{"bash", "zsh", "sh", "fish", "dash", "ksh", "tcsh"}
+
+type paneActivity struct {
+	content   string
+	changedAt time.Time
+}
+
+var _ orchestrator.WorkerManager = (*PaneWorkerPool)(nil)
+
+// NewPaneWorkerPool creates an empty pool; panes are added by CreateWorker,
+// Register or Sync
+func NewPaneWorkerPool(manager *Manager, workersConfig config.WorkersConfig) *PaneWorkerPool {
+	if workersConfig.MaxWorkers <= 0 {
+		workersConfig.MaxWorkers = config.NewOrchestratorConfig().Workers.MaxWorkers
+	}
+
+	return &PaneWorkerPool{
+		manager:      manager,
+		config:       workersConfig,
+		workers:      make(map[string]*orchestrator.Worker),
+		pollInterval: 1 * time.Second,
+		health: WorkerHealthConfig{
+			Interval:     10 * time.Second,
+			StaleTimeout: 10 * time.Minute,
+		},
+		activity:  make(map[string]*paneActivity),
+		ranClaude: make(map[string]bool),
+		avoid:     make(map[string]string),
+	}
+}
+
+// SetHealthConfig changes how workers are monitored; zero fields keep their
+// current value
+func (p *PaneWorkerPool) SetHealthConfig(health WorkerHealthConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if health.Interval > 0 {
+		p.health.Interval = health.Interval
+	}
+	if health.StaleTimeout > 0 {
+		p.health.StaleTimeout = health.StaleTimeout
+	}
+}
+
+// SetRequeueFunc sets how the step of a lost worker is retried
+func (p *PaneWorkerPool) SetRequeueFunc(requeue RequeueFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requeue = requeue
+}
+
+// SetStorage makes worker state changes be persisted
+func (p *PaneWorkerPool) SetStorage(storage orchestrator.Storage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.storage = storage
+}
+
+// CreateWorker opens a new child pane, starts Claude in it and registers it
+func (p *PaneWorkerPool) CreateWorker(ctx context.Context, workerConfig orchestrator.WorkerConfig) (*orchestrator.Worker, error) {
+	p.mu.Lock()
+	if p.activeCount() >= p.config.MaxWorkers {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("worker limit reached (%d)", p.config.MaxWorkers)
+	}
+	// Reserve the slot while the pane starts up
+	p.order = append(p.order, "")
+	p.mu.Unlock()
+
+	paneID, err := p.manager.CreateNewPaneAndRegisterAsChild()
+	if err == nil {
+		if err = p.manager.StartClaudeInPane(ctx, paneID); err != nil {
+			// Left behind, the pane would be adopted by Sync
+			p.manager.mux.KillPane(paneID)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.removeFromOrder("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worker: %w", err)
+	}
+
+	p.ranClaude[paneID] = true
+	worker := p.register(ctx, paneID, workerConfig)
+	return copyWorker(worker), nil
+}
+
+// Register adopts an existing pane that already runs Claude as a worker
+func (p *PaneWorkerPool) Register(ctx context.Context, paneID string, workerConfig orchestrator.WorkerConfig) (*orchestrator.Worker, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if worker, exists := p.workers[paneID]; exists {
+		return copyWorker(worker), nil
+	}
+	if p.activeCount() >= p.config.MaxWorkers {
+		return nil, fmt.Errorf("worker limit reached (%d)", p.config.MaxWorkers)
+	}
+
+	worker := p.register(ctx, paneID, workerConfig)
+	return copyWorker(worker), nil
+}
+
+// Restore re-registers the workers persisted by a previous process whose
+// panes still exist, keeping their name and role. A worker keeps its
+// assignment when it is one of the running steps, so that the step can be
+// waited on instead of dispatched again; other workers come back idle.
+// Workers whose pane is gone are removed from storage. It returns the
+// workers that kept their step.
+func (p *PaneWorkerPool) Restore(ctx context.Context, running []string) ([]*orchestrator.Worker, error) {
+	if p.storage == nil {
+		return nil, nil
+	}
+	persisted, err := p.storage.ListWorkers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workers: %w", err)
+	}
+	panes, err := p.manager.GetChildPanes()
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[string]bool, len(panes))
+	for _, pane := range panes {
+		present[pane] = true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var busy []*orchestrator.Worker
+	for _, saved := range persisted {
+		if _, exists := p.workers[saved.ID]; exists {
+			continue
+		}
+		if !present[saved.ID] || saved.Status == orchestrator.WorkerStatusOffline || p.activeCount() >= p.config.MaxWorkers {
+			p.storage.DeleteWorker(ctx, saved.ID)
+			continue
+		}
+
+		worker := copyWorker(saved)
+		worker.Status = orchestrator.WorkerStatusIdle
+		if worker.CurrentTask != nil && containsString(running, *worker.CurrentTask) {
+			worker.Status = orchestrator.WorkerStatusBusy
+			busy = append(busy, copyWorker(worker))
+		} else {
+			worker.CurrentTask = nil
+		}
+		worker.LastSeen = time.Now()
+		p.workers[worker.ID] = worker
+		p.order = append(p.order, worker.ID)
+		p.save(ctx, worker)
+	}
+	return busy, nil
+}
+
+// Sync adopts child panes not yet in the pool that run Claude or carry a
+// worker label, and recovers workers whose pane has disappeared. Other panes,
+// such as a shell the user split off, are left alone.
+func (p *PaneWorkerPool) Sync(ctx context.Context) error {
+	panes, err := p.manager.mux.ListPanes(p.manager.SessionName)
+	if err != nil {
+		return fmt.Errorf("failed to get panes: %v", err)
+	}
+
+	p.mu.Lock()
+	present := make(map[string]bool, len(panes))
+	for _, pane := range panes {
+		if !p.manager.IsChildPane(pane.ID) {
+			continue
+		}
+		present[pane.ID] = true
+		if _, exists := p.workers[pane.ID]; exists || !p.adoptable(pane) || p.activeCount() >= p.config.MaxWorkers {
+			continue
+		}
+		if containsString(claudeCommands, pane.Command) {
+			p.ranClaude[pane.ID] = true
+		}
+		p.register(ctx, pane.ID, orchestrator.WorkerConfig{})
+	}
+
+	var vanished []string
+	for _, id := range p.order {
+		if worker, exists := p.workers[id]; exists && !present[id] && worker.Status != orchestrator.WorkerStatusOffline {
+			vanished = append(vanished, id)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, id := range vanished {
+		p.recoverWorker(ctx, id, fmt.Errorf("%w: pane %s no longer exists", ErrWorkerDead, id))
+	}
+	return nil
+}
+
+// adoptable reports whether Sync may take the pane as a worker: Claude runs
+// in it, or it is titled with the worker label prefix (see LabelWorker)
+func (p *PaneWorkerPool) adoptable(pane PaneInfo) bool {
+	if containsString(claudeCommands, pane.Command) {
+		return true
+	}
+	prefix := p.manager.config.Session.PanePrefix
+	return prefix != "" && strings.HasPrefix(pane.Title, prefix+"-")
+}
+
+func (p *PaneWorkerPool) GetWorker(ctx context.Context, workerID string) (*orchestrator.Worker, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	worker, exists := p.workers[workerID]
+	if !exists {
+		return nil, fmt.Errorf("worker %s not found", workerID)
+	}
+	return copyWorker(worker), nil
+}
+
+// Workers returns a snapshot of all workers in registration order
+func (p *PaneWorkerPool) Workers() []*orchestrator.Worker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	workers := make([]*orchestrator.Worker, 0, len(p.order))
+	for _, id := range p.order {
+		if worker, exists := p.workers[id]; exists {
+			workers = append(workers, copyWorker(worker))
+		}
+	}
+	return workers
+}
+
+func (p *PaneWorkerPool) UpdateWorker(ctx context.Context, workerID string, updates orchestrator.WorkerUpdate) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	worker, exists := p.workers[workerID]
+	if !exists {
+		return fmt.Errorf("worker %s not found", workerID)
+	}
+
+	if updates.Capabilities != nil {
+		worker.Capabilities = append([]string(nil), updates.Capabilities...)
+	}
+	if updates.Status != nil {
+		p.setStatus(ctx, worker, *updates.Status)
+		return nil
+	}
+	p.save(ctx, worker)
+	return nil
+}
+
+// RemoveWorker kills the worker pane and forgets the worker
+func (p *PaneWorkerPool) RemoveWorker(ctx context.Context, workerID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.workers[workerID]; !exists {
+		return fmt.Errorf("worker %s not found", workerID)
+	}
+
+	p.manager.mux.KillPane(workerID)
+	p.forget(ctx, workerID)
+	return nil
+}
+
+// FindAvailableWorker returns an idle worker having every required capability
+func (p *PaneWorkerPool) FindAvailableWorker(ctx context.Context, requirements orchestrator.WorkerRequirements) (*orchestrator.Worker, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if worker := p.findIdle(requirements.Capabilities, ""); worker != nil {
+		return copyWorker(worker), nil
+	}
+	return nil, fmt.Errorf("no idle worker with capabilities %v", requirements.Capabilities)
+}
+
+func (p *PaneWorkerPool) AssignTask(ctx context.Context, workerID string, taskID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	worker, exists := p.workers[workerID]
+	if !exists {
+		return fmt.Errorf("worker %s not found", workerID)
+	}
+	if worker.Status != orchestrator.WorkerStatusIdle {
+		return fmt.Errorf("worker %s is %s", workerID, worker.Status)
+	}
+
+	p.assign(ctx, worker, taskID)
+	return nil
+}
+
+func (p *PaneWorkerPool) UnassignTask(ctx context.Context, workerID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	worker, exists := p.workers[workerID]
+	if !exists {
+		return fmt.Errorf("worker %s not found", workerID)
+	}
+
+	worker.CurrentTask = nil
+	if worker.Status == orchestrator.WorkerStatusBusy {
+		p.setStatus(ctx, worker, orchestrator.WorkerStatusIdle)
+		return nil
+	}
+	p.save(ctx, worker)
+	return nil
+}
+
+// Release makes the worker idle again if it is still assigned taskID. A
+// worker that was taken over by MonitorWorkers in the meantime is left alone.
+func (p *PaneWorkerPool) Release(ctx context.Context, workerID string, taskID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	worker, exists := p.workers[workerID]
+	if !exists || worker.CurrentTask == nil || *worker.CurrentTask != taskID {
+		return
+	}
+	worker.CurrentTask = nil
+	if worker.Status == orchestrator.WorkerStatusBusy {
+		p.setStatus(ctx, worker, orchestrator.WorkerStatusIdle)
+	}
+}
+
+// Acquire assigns taskID to an idle worker matching the requirements. A new
+// worker is started when none is idle and the pool is below MaxWorkers;
+// otherwise Acquire waits until a worker is released or ctx is done.
+func (p *PaneWorkerPool) Acquire(ctx context.Context, requirements orchestrator.WorkerRequirements, taskID string) (*orchestrator.Worker, error) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		p.mu.Lock()
+		avoid := p.avoid[taskID]
+		worker := p.findIdle(requirements.Capabilities, avoid)
+		if worker == nil && !p.hasCapabilities(requirements.Capabilities) && p.activeCount() >= p.config.MaxWorkers {
+			// No worker can ever match, so fall back to any idle one
+			worker = p.findIdle(nil, avoid)
+		}
+		if worker == nil && avoid != "" && p.config.MaxWorkers <= 1 {
+			// The avoided worker is the only one there can be
+			worker = p.findIdle(nil, "")
+		}
+		if worker != nil {
+			delete(p.avoid, taskID)
+			p.assign(ctx, worker, taskID)
+			p.mu.Unlock()
+			return copyWorker(worker), nil
+		}
+		canCreate := p.activeCount() < p.config.MaxWorkers
+		role := ""
+		if canCreate {
+			role = p.roleFor(requirements.Capabilities)
+		}
+		p.mu.Unlock()
+
+		if canCreate {
+			created, err := p.CreateWorker(ctx, orchestrator.WorkerConfig{Type: role})
+			if err != nil {
+				return nil, err
+			}
+			if err := p.AssignTask(ctx, created.ID, taskID); err == nil {
+				p.mu.Lock()
+				delete(p.avoid, taskID)
+				p.mu.Unlock()
+				return p.GetWorker(ctx, created.ID)
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WorkerForTask returns the worker currently assigned taskID, or nil
+func (p *PaneWorkerPool) WorkerForTask(taskID string) *orchestrator.Worker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, id := range p.order {
+		worker, exists := p.workers[id]
+		if exists && worker.CurrentTask != nil && *worker.CurrentTask == taskID {
+			return copyWorker(worker)
+		}
+	}
+	return nil
+}
+
+// Avoid makes the next Acquire for taskID pick a worker other than workerID
+// when one is available, e.g. to move a step to another worker
+func (p *PaneWorkerPool) Avoid(taskID, workerID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.avoid[taskID] = workerID
+}
+
+// Interrupt stops what Claude is doing in the worker pane (like pressing Esc)
+func (p *PaneWorkerPool) Interrupt(workerID string) error {
+	if err := p.manager.mux.SendKeys(workerID, "Escape"); err != nil {
+		return fmt.Errorf("failed to interrupt worker %s: %v", workerID, err)
+	}
+	return nil
+}
+
+// HealthCheck inspects the worker pane. It fails with ErrWorkerDead when the
+// pane is gone, dead or back at a shell prompt, and with ErrWorkerHung when a
+// busy worker has produced no output for StaleTimeout.
+func (p *PaneWorkerPool) HealthCheck(ctx context.Context, workerID string) error {
+	p.mu.Lock()
+	_, exists := p.workers[workerID]
+	p.mu.Unlock()
+	if !exists {
+		return fmt.Errorf("worker %s not found", workerID)
+	}
+
+	// The pane ID is compared in case the target resolves to another pane
+	pane, err := p.manager.mux.DescribePane(workerID)
+	if err != nil || pane == nil || pane.ID != workerID {
+		return fmt.Errorf("%w: pane %s no longer exists", ErrWorkerDead, workerID)
+	}
+	if pane.Dead {
+		return fmt.Errorf("%w: pane %s exited", ErrWorkerDead, workerID)
+	}
+	if containsString(shellCommands, pane.Command) {
+		return fmt.Errorf("%w: claude exited to %s in pane %s", ErrWorkerDead, pane.Command, workerID)
+	}
+	if containsString(claudeCommands, pane.Command) {
+		p.mu.Lock()
+		p.ranClaude[workerID] = true
+		p.mu.Unlock()
+	}
+
+	content, err := p.manager.CapturePane(workerID, 50)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWorkerDead, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	worker, exists := p.workers[workerID]
+	if !exists {
+		return fmt.Errorf("worker %s not found", workerID)
+	}
+
+	now := time.Now()
+	activity, seen := p.activity[workerID]
+	if !seen || activity.content != content {
+		p.activity[workerID] = &paneActivity{content: content, changedAt: now}
+	} else if worker.Status == orchestrator.WorkerStatusBusy && now.Sub(activity.changedAt) > p.health.StaleTimeout {
+		return fmt.Errorf("%w: no output in pane %s for %s", ErrWorkerHung, workerID, now.Sub(activity.changedAt).Round(time.Second))
+	}
+
+	worker.LastSeen = now
+	p.save(ctx, worker)
+	return nil
+}
+
+// MonitorWorkers health-checks every worker periodically until ctx is done.
+// Dead or hung workers are marked offline, their in-flight step is requeued
+// and Claude is restarted in their pane. A worker whose pane never ran Claude
+// is dropped instead, leaving the pane alone.
+func (p *PaneWorkerPool) MonitorWorkers(ctx context.Context) error {
+	p.mu.Lock()
+	interval := p.health.Interval
+	p.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, worker := range p.Workers() {
+			if worker.Status == orchestrator.WorkerStatusOffline {
+				continue
+			}
+			if err := p.HealthCheck(ctx, worker.ID); err != nil && (errors.Is(err, ErrWorkerDead) || errors.Is(err, ErrWorkerHung)) {
+				p.recoverWorker(ctx, worker.ID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// recoverWorker takes a lost worker offline, requeues its step and respawns it
+func (p *PaneWorkerPool) recoverWorker(ctx context.Context, workerID string, cause error) {
+	p.mu.Lock()
+	worker, exists := p.workers[workerID]
+	if !exists || worker.Status == orchestrator.WorkerStatusOffline {
+		p.mu.Unlock()
+		return
+	}
+	inflight := ""
+	if worker.CurrentTask != nil {
+		inflight = *worker.CurrentTask
+	}
+	worker.CurrentTask = nil
+	p.setStatus(ctx, worker, orchestrator.WorkerStatusOffline)
+	lost := copyWorker(worker)
+	requeue := p.requeue
+	ranClaude := p.ranClaude[workerID]
+	p.mu.Unlock()
+
+	fmt.Printf("⚠️  Worker %s lost: %v\n", lost.Name, cause)
+
+	if inflight != "" && requeue != nil {
+		if err := requeue(ctx, inflight, cause.Error()); err != nil {
+			fmt.Printf("⚠️  Failed to requeue step %s: %v\n", inflight, err)
+		} else {
+			fmt.Printf("🔁 Requeued step %s\n", inflight)
+		}
+	}
+
+	if !ranClaude {
+		p.mu.Lock()
+		p.forget(ctx, workerID)
+		p.mu.Unlock()
+		fmt.Printf("🚫 Dropped worker %s: Claude never ran in pane %s\n", lost.Name, workerID)
+		return
+	}
+
+	if err := p.respawn(ctx, lost); err != nil {
+		fmt.Printf("❌ Failed to respawn worker %s: %v\n", lost.Name, err)
+	}
+}
+
+// respawn restarts Claude in the worker pane, or replaces the worker with a
+// new pane when its pane is gone. When Claude does not come back the pane is
+// killed and the worker forgotten, so that a later CreateWorker replaces it.
+func (p *PaneWorkerPool) respawn(ctx context.Context, worker *orchestrator.Worker) error {
+	if err := p.manager.mux.RespawnPane(worker.ID); err != nil {
+		p.mu.Lock()
+		p.forget(ctx, worker.ID)
+		p.mu.Unlock()
+
+		replacement, err := p.CreateWorker(ctx, orchestrator.WorkerConfig{
+			Name:         worker.Name,
+			Type:         worker.Type,
+			Capabilities: worker.Capabilities,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("♻️  Worker %s replaced by pane %s\n", worker.Name, replacement.ID)
+		return nil
+	}
+
+	if err := p.manager.StartClaudeInPane(ctx, worker.ID); err != nil {
+		p.manager.mux.KillPane(worker.ID)
+		p.mu.Lock()
+		p.forget(ctx, worker.ID)
+		p.mu.Unlock()
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if current, exists := p.workers[worker.ID]; exists {
+		delete(p.activity, worker.ID)
+		p.setStatus(ctx, current, orchestrator.WorkerStatusIdle)
+	}
+	fmt.Printf("♻️  Worker %s respawned in pane %s\n", worker.Name, worker.ID)
+	return nil
+}
+
+// register must be called with p.mu held
+func (p *PaneWorkerPool) register(ctx context.Context, paneID string, workerConfig orchestrator.WorkerConfig) *orchestrator.Worker {
+	role := workerConfig.Type
+	if role == "" {
+		role = p.roleFor(workerConfig.Capabilities)
+	}
+	capabilities := workerConfig.Capabilities
+	if len(capabilities) == 0 && role != "" {
+		capabilities = []string{role}
+	}
+	name := workerConfig.Name
+	for n := len(p.workers) + 1; name == "" || p.nameTaken(name); n++ {
+		name = fmt.Sprintf("worker-%d", n)
+	}
+
+	worker := &orchestrator.Worker{
+		ID:           paneID,
+		Name:         name,
+		Type:         role,
+		Status:       orchestrator.WorkerStatusIdle,
+		Capabilities: append([]string(nil), capabilities...),
+		LastSeen:     time.Now(),
+	}
+	p.workers[paneID] = worker
+	p.order = append(p.order, paneID)
+	p.save(ctx, worker)
+
+	if err := p.manager.LabelWorker(paneID, name, role); err != nil {
+		fmt.Printf("⚠️  Failed to label pane %s: %v\n", paneID, err)
+	}
+	fmt.Printf("👷 Registered worker %s (%s) in pane %s\n", worker.Name, role, paneID)
+	return worker
+}
+
+// nameTaken reports whether a worker already has the name
+func (p *PaneWorkerPool) nameTaken(name string) bool {
+	for _, worker := range p.workers {
+		if worker.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// roleFor picks the role for a new worker: a requested capability that is a
+// configured role, otherwise the configured roles in turn
+func (p *PaneWorkerPool) roleFor(capabilities []string) string {
+	for _, capability := range capabilities {
+		if containsString(p.config.Roles, capability) {
+			return capability
+		}
+	}
+	if len(p.config.Roles) == 0 {
+		return ""
+	}
+	role := p.config.Roles[p.nextRole%len(p.config.Roles)]
+	p.nextRole++
+	return role
+}
+
+// findIdle returns an idle worker other than except having the capabilities
+func (p *PaneWorkerPool) findIdle(capabilities []string, except string) *orchestrator.Worker {
+	for _, id := range p.order {
+		worker, exists := p.workers[id]
+		if exists && id != except && worker.Status == orchestrator.WorkerStatusIdle && hasAll(worker.Capabilities, capabilities) {
+			return worker
+		}
+	}
+	return nil
+}
+
+// hasCapabilities reports whether any live worker could serve the capabilities
+func (p *PaneWorkerPool) hasCapabilities(capabilities []string) bool {
+	for _, worker := range p.workers {
+		if worker.Status != orchestrator.WorkerStatusOffline && hasAll(worker.Capabilities, capabilities) {
+			return true
+		}
+	}
+	return false
+}
+
+// activeCount counts workers that hold a pane, including reserved slots
+func (p *PaneWorkerPool) activeCount() int {
+	count := 0
+	for _, id := range p.order {
+		if worker, exists := p.workers[id]; !exists || worker.Status != orchestrator.WorkerStatusOffline {
+			count++
+		}
+	}
+	return count
+}
+
+func (p *PaneWorkerPool) assign(ctx context.Context, worker *orchestrator.Worker, taskID string) {
+	task := taskID
+	worker.CurrentTask = &task
+	p.setStatus(ctx, worker, orchestrator.WorkerStatusBusy)
+}
+
+func (p *PaneWorkerPool) setStatus(ctx context.Context, worker *orchestrator.Worker, status orchestrator.WorkerStatus) {
+	worker.Status = status
+	worker.LastSeen = time.Now()
+	p.save(ctx, worker)
+}
+
+func (p *PaneWorkerPool) save(ctx context.Context, worker *orchestrator.Worker) {
+	if p.storage != nil {
+		p.storage.SaveWorker(ctx, worker)
+	}
+}
+
+// forget must be called with p.mu held
+func (p *PaneWorkerPool) forget(ctx context.Context, workerID string) {
+	delete(p.workers, workerID)
+	delete(p.activity, workerID)
+	delete(p.ranClaude, workerID)
+	p.removeFromOrder(workerID)
+	if p.storage != nil {
+		p.storage.DeleteWorker(ctx, workerID)
+	}
+}
+
+func (p *PaneWorkerPool) removeFromOrder(id string) {
+	for i, existing := range p.order {
+		if existing == id {
+			p.order = append(p.order[:i], p.order[i+1:]...)
+			return
+		}
+	}
+}
+
+func copyWorker(worker *orchestrator.Worker) *orchestrator.Worker {
+	c := *worker
+	c.Capabilities = append([]string(nil), worker.Capabilities...)
+	if worker.CurrentTask != nil {
+		task := *worker.CurrentTask
+		c.CurrentTask = &task
+	}
+	return &c
+}
+
+func hasAll(values, required []string) bool {
+	for _, r := range required {
+		if !containsString(values, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// stepCapabilities maps a plan step to the worker role best suited for it
+func stepCapabilities(stepType orchestrator.StepType) []string {
+	switch stepType {
+	case orchestrator.StepTypeImplementation:
+		return []string{"developer"}
+	case orchestrator.StepTypeTesting:
+		return []string{"tester"}
+	case orchestrator.StepTypeReview:
+		return []string{"reviewer"}
+	default:
+		return nil
+	}
+}
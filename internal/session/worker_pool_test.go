@@ -0,0 +1,343 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"claude-company/internal/config"
+	"claude-company/internal/orchestrator"
+)
+
+// newPoolManager returns a fake-driven manager whose session "test" has one
+// parent pane, %0. Workers start within milliseconds: a stub claude is put on
+// PATH and the readiness probe only waits for the process and input box.
+func newPoolManager(t *testing.T, maxWorkers int) (*Manager, *FakeMultiplexer) {
+	t.Helper()
+	m, fake := newFakeManager(t)
+	stubClaude(t)
+
+	m.ReadinessProbe().SetDetectors(&ProcessDetector{}, &PromptBoxDetector{})
+	m.ReadinessProbe().Interval = 10 * time.Millisecond
+	m.SetReadinessTimeout(500 * time.Millisecond)
+	m.SetWorkersConfig(config.WorkersConfig{MaxWorkers: maxWorkers})
+	m.WorkerPool().pollInterval = 10 * time.Millisecond
+
+	if err := fake.NewSession("test", "main"); err != nil {
+		t.Fatal(err)
+	}
+	m.ParentPanes["%0"] = true
+	return m, fake
+}
+
+// stubClaude puts an executable named claude first on PATH, so that the
+// Claude binary check passes
+func stubClaude(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "claude"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// fakePaneIDs returns the IDs of the panes of session "test"
+func fakePaneIDs(t *testing.T, fake *FakeMultiplexer) []string {
+	t.Helper()
+	panes, err := fake.ListPanes("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return paneIDs(panes)
+}
+
+// eventually fails the test unless cond becomes true within two seconds
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting until %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAcquireRelease(t *testing.T) {
+	m, fake := newPoolManager(t, 2)
+	pool := m.WorkerPool()
+	ctx := context.Background()
+
+	first, err := pool.Acquire(ctx, orchestrator.WorkerRequirements{}, "step-1")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	second, err := pool.Acquire(ctx, orchestrator.WorkerRequirements{}, "step-2")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if first.ID == second.ID {
+		t.Fatalf("both steps got worker %s", first.ID)
+	}
+	for _, worker := range []*orchestrator.Worker{first, second} {
+		if worker.Status != orchestrator.WorkerStatusBusy {
+			t.Errorf("worker %s is %s, want busy", worker.ID, worker.Status)
+		}
+		if got := fake.Sent(worker.ID); len(got) != 1 || got[0] != "claude" {
+			t.Errorf("pane %s got %q, want Claude started", worker.ID, got)
+		}
+	}
+
+	// The pool is full: the third step waits for a worker to be released
+	acquired := make(chan *orchestrator.Worker)
+	go func() {
+		worker, err := pool.Acquire(ctx, orchestrator.WorkerRequirements{}, "step-3")
+		if err != nil {
+			t.Errorf("Acquire: %v", err)
+		}
+		acquired <- worker
+	}()
+
+	select {
+	case worker := <-acquired:
+		t.Fatalf("step-3 got worker %s while the pool was full", worker.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Releasing a step the worker no longer holds changes nothing
+	pool.Release(ctx, first.ID, "step-2")
+	if worker, _ := pool.GetWorker(ctx, first.ID); worker.Status != orchestrator.WorkerStatusBusy {
+		t.Errorf("worker %s is %s after releasing another step, want busy", first.ID, worker.Status)
+	}
+
+	pool.Release(ctx, first.ID, "step-1")
+	select {
+	case worker := <-acquired:
+		if worker.ID != first.ID || worker.CurrentTask == nil || *worker.CurrentTask != "step-3" {
+			t.Errorf("step-3 got %+v, want released worker %s", worker, first.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("step-3 did not get the released worker")
+	}
+
+	if panes := fakePaneIDs(t, fake); len(panes) != 3 {
+		t.Errorf("session has panes %v, want the parent pane and 2 workers", panes)
+	}
+}
+
+func TestAcquireCancelled(t *testing.T) {
+	m, _ := newPoolManager(t, 1)
+	pool := m.WorkerPool()
+
+	if _, err := pool.Acquire(context.Background(), orchestrator.WorkerRequirements{}, "step-1"); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Acquire(ctx, orchestrator.WorkerRequirements{}, "step-2"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire on a full pool = %v, want the context error", err)
+	}
+}
+
+func TestCreateWorkerFailureKillsPane(t *testing.T) {
+	m, fake := newPoolManager(t, 2)
+	fake.SetResponder(nil) // Claude never starts
+
+	if _, err := m.WorkerPool().CreateWorker(context.Background(), orchestrator.WorkerConfig{}); !errors.Is(err, ErrReadyTimeout) {
+		t.Fatalf("CreateWorker = %v, want %v", err, ErrReadyTimeout)
+	}
+	if panes := fakePaneIDs(t, fake); len(panes) != 1 {
+		t.Errorf("session has panes %v, want the worker pane killed", panes)
+	}
+	if workers := m.WorkerPool().Workers(); len(workers) != 0 {
+		t.Errorf("pool has workers %v, want none", workers)
+	}
+}
+
+func TestSyncAdoptsOnlyWorkerPanes(t *testing.T) {
+	m, fake := newPoolManager(t, 4)
+
+	claudePane, _ := fake.Split("%0")
+	labelledPane, _ := fake.Split("%0")
+	shellPane, _ := fake.Split("%0")
+	fake.SetPaneCommand(claudePane, "claude")
+	fake.SetPaneTitle(labelledPane, "claude-worker-1")
+
+	if err := m.WorkerPool().Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	var adopted []string
+	for _, worker := range m.WorkerPool().Workers() {
+		adopted = append(adopted, worker.ID)
+	}
+	want := []string{claudePane, labelledPane}
+	if len(adopted) != len(want) || adopted[0] != want[0] || adopted[1] != want[1] {
+		t.Errorf("adopted %v, want %v and not the shell pane %s", adopted, want, shellPane)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	m, fake := newPoolManager(t, 1)
+	pool := m.WorkerPool()
+	ctx := context.Background()
+
+	worker, err := pool.CreateWorker(ctx, orchestrator.WorkerConfig{})
+	if err != nil {
+		t.Fatalf("CreateWorker: %v", err)
+	}
+	if err := pool.HealthCheck(ctx, worker.ID); err != nil {
+		t.Errorf("HealthCheck of a running worker: %v", err)
+	}
+
+	fake.SetPaneCommand(worker.ID, "bash")
+	if err := pool.HealthCheck(ctx, worker.ID); !errors.Is(err, ErrWorkerDead) {
+		t.Errorf("HealthCheck after Claude exited = %v, want %v", err, ErrWorkerDead)
+	}
+
+	fake.SetPaneCommand(worker.ID, "claude")
+	fake.SetPaneDead(worker.ID, true)
+	if err := pool.HealthCheck(ctx, worker.ID); !errors.Is(err, ErrWorkerDead) {
+		t.Errorf("HealthCheck of a dead pane = %v, want %v", err, ErrWorkerDead)
+	}
+
+	fake.KillPane(worker.ID)
+	if err := pool.HealthCheck(ctx, worker.ID); !errors.Is(err, ErrWorkerDead) {
+		t.Errorf("HealthCheck of a killed pane = %v, want %v", err, ErrWorkerDead)
+	}
+}
+
+func TestHealthCheckHung(t *testing.T) {
+	m, _ := newPoolManager(t, 1)
+	pool := m.WorkerPool()
+	pool.SetHealthConfig(WorkerHealthConfig{StaleTimeout: 20 * time.Millisecond})
+	ctx := context.Background()
+
+	worker, err := pool.Acquire(ctx, orchestrator.WorkerRequirements{}, "step-1")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if err := pool.HealthCheck(ctx, worker.ID); err != nil {
+		t.Fatalf("HealthCheck: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if err := pool.HealthCheck(ctx, worker.ID); !errors.Is(err, ErrWorkerHung) {
+		t.Errorf("HealthCheck of a silent busy worker = %v, want %v", err, ErrWorkerHung)
+	}
+}
+
+// monitor runs MonitorWorkers until the test ends, recording requeued steps
+func monitor(t *testing.T, pool *PaneWorkerPool) func() []string {
+	t.Helper()
+	var mu sync.Mutex
+	var requeued []string
+	pool.SetRequeueFunc(func(ctx context.Context, stepID string, reason string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		requeued = append(requeued, stepID)
+		return nil
+	})
+	pool.SetHealthConfig(WorkerHealthConfig{Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pool.MonitorWorkers(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requeued...)
+	}
+}
+
+func TestMonitorRespawnsWorker(t *testing.T) {
+	m, fake := newPoolManager(t, 1)
+	pool := m.WorkerPool()
+
+	worker, err := pool.Acquire(context.Background(), orchestrator.WorkerRequirements{}, "step-1")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	requeued := monitor(t, pool)
+
+	fake.SetPaneCommand(worker.ID, "bash") // Claude exited
+	eventually(t, "Claude is restarted", func() bool {
+		return len(fake.Sent(worker.ID)) == 2
+	})
+	eventually(t, "the worker is idle", func() bool {
+		w, err := pool.GetWorker(context.Background(), worker.ID)
+		return err == nil && w.Status == orchestrator.WorkerStatusIdle
+	})
+
+	if !hasCommand(fake, "respawn-pane", "-k", "-t", worker.ID) {
+		t.Errorf("pane %s was not respawned; commands: %v", worker.ID, fake.Commands())
+	}
+	if got := requeued(); len(got) != 1 || got[0] != "step-1" {
+		t.Errorf("requeued %v, want [step-1]", got)
+	}
+}
+
+func TestMonitorDropsUserPane(t *testing.T) {
+	m, fake := newPoolManager(t, 1)
+	pool := m.WorkerPool()
+
+	// A shell pane carrying a worker label, in which Claude never ran
+	shellPane, _ := fake.Split("%0")
+	fake.SetPaneTitle(shellPane, "claude-worker-1")
+	if err := pool.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(pool.Workers()) != 1 {
+		t.Fatalf("labelled pane %s was not adopted", shellPane)
+	}
+	monitor(t, pool)
+
+	eventually(t, "the worker is dropped", func() bool {
+		return len(pool.Workers()) == 0
+	})
+	if hasCommand(fake, "respawn-pane") || hasCommand(fake, "kill-pane") {
+		t.Errorf("user pane was respawned or killed; commands: %v", fake.Commands())
+	}
+	if panes := fakePaneIDs(t, fake); len(panes) != 2 {
+		t.Errorf("session has panes %v, want the user pane kept", panes)
+	}
+}
+
+func TestMonitorForgetsWorkerFailingToRespawn(t *testing.T) {
+	m, fake := newPoolManager(t, 1)
+	pool := m.WorkerPool()
+
+	worker, err := pool.CreateWorker(context.Background(), orchestrator.WorkerConfig{})
+	if err != nil {
+		t.Fatalf("CreateWorker: %v", err)
+	}
+	monitor(t, pool)
+
+	fake.SetResponder(nil) // Claude no longer starts
+	fake.SetPaneCommand(worker.ID, "bash")
+
+	eventually(t, "the worker is forgotten", func() bool {
+		return len(pool.Workers()) == 0
+	})
+	if !hasCommand(fake, "kill-pane", "-t", worker.ID) {
+		t.Errorf("pane %s was not killed; commands: %v", worker.ID, fake.Commands())
+	}
+
+	// The freed slot lets a new worker be created
+	fake.EmulateClaude("claude")
+	if _, err := pool.CreateWorker(context.Background(), orchestrator.WorkerConfig{}); err != nil {
+		t.Errorf("CreateWorker after the lost worker: %v", err)
+	}
+}
@@ -0,0 +1,621 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"claude-company/internal/orchestrator"
+	"claude-company/internal/session"
+)
+
+// maxLogLines is how many event log lines the dashboard keeps
+const maxLogLines = 500
+
+// Dashboard is a full-screen terminal UI that executes a plan and shows it
+// live: the step DAG, each worker with the tail of its pane and the event
+// log. Steps can be retried, cancelled or moved to another worker.
+type Dashboard struct {
+	manager *session.Manager
+	planID  string
+	title   string
+	// TailLines is how many lines of each worker pane are shown
+	TailLines int
+
+	mu       sync.Mutex
+	log      []string
+	steps    []orchestrator.TaskStep
+	workers  []*orchestrator.Worker
+	tails    map[string][]string
+	selected int
+	message  string
+	running  bool
+	result   error
+	retried  []string // steps to run again once the current execution ends
+	quitting bool
+	redraw   chan struct{}
+	done     chan error
+}
+
+// NewDashboard creates a dashboard for a plan prepared on manager
+func NewDashboard(manager *session.Manager, planID, title string) *Dashboard {
+	return &Dashboard{
+		manager:   manager,
+		planID:    planID,
+		title:     title,
+		TailLines: 3,
+		tails:     make(map[string][]string),
+		redraw:    make(chan struct{}, 1),
+		done:      make(chan error, 1),
+	}
+}
+
+// Run executes the plan and shows the dashboard until the user quits. It
+// returns the error of the plan execution, if it finished.
+func (d *Dashboard) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	bus := d.manager.EventBus()
+	if bus == nil {
+		return fmt.Errorf("orchestrator not initialized")
+	}
+	events, err := bus.Subscribe(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+
+	term, err := openTerminal(os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer term.Close()
+
+	restore, err := d.captureStdout()
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	keys := make(chan key, 16)
+	go readKeys(os.Stdin, keys)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	d.start(ctx)
+	d.refreshTails()
+	for {
+		d.refreshSteps(ctx)
+		rows, cols := term.Size()
+		term.Draw(d.render(rows, cols))
+
+		select {
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			d.addLog(formatEvent(event))
+		case <-d.redraw:
+		case <-ticker.C:
+			d.refreshTails()
+		case err := <-d.done:
+			d.finished(ctx, err)
+		case k := <-keys:
+			if d.handleKey(ctx, k) {
+				return d.stop(cancel)
+			}
+		}
+	}
+}
+
+// start executes the plan in the background
+func (d *Dashboard) start(ctx context.Context) {
+	d.mu.Lock()
+	d.running = true
+	d.result = nil
+	d.mu.Unlock()
+	d.addLog("▶️  Executing plan " + d.planID)
+
+	go func() {
+		d.done <- d.manager.ExecutePlan(ctx, d.planID)
+	}()
+}
+
+// finished records the end of a plan execution and executes the plan again
+// if steps were retried while it was ending
+func (d *Dashboard) finished(ctx context.Context, err error) {
+	d.mu.Lock()
+	d.running = false
+	d.result = err
+	retried := d.retried
+	d.retried = nil
+	d.mu.Unlock()
+
+	if err != nil {
+		d.addLog("❌ Plan failed: " + err.Error())
+	} else {
+		d.addLog("✅ Plan completed")
+	}
+
+	d.refreshSteps(ctx)
+	for _, stepID := range retried {
+		if step := d.step(stepID); step != nil && step.Status == orchestrator.TaskStatusPending {
+			d.start(ctx)
+			return
+		}
+	}
+}
+
+// stop ends the dashboard, cancelling the plan if it still runs
+func (d *Dashboard) stop(cancel context.CancelFunc) error {
+	d.mu.Lock()
+	running := d.running
+	d.mu.Unlock()
+	if !running {
+		return d.result
+	}
+
+	cancel()
+	select {
+	case <-d.done:
+	case <-time.After(5 * time.Second):
+	}
+	return fmt.Errorf("stopped before plan %s finished", d.planID)
+}
+
+func (d *Dashboard) handleKey(ctx context.Context, k key) bool {
+	d.mu.Lock()
+	if k != keyQuit {
+		d.quitting = false
+	}
+	switch k {
+	case keyUp:
+		if d.selected > 0 {
+			d.selected--
+		}
+		d.mu.Unlock()
+		return false
+	case keyDown:
+		if d.selected < len(d.steps)-1 {
+			d.selected++
+		}
+		d.mu.Unlock()
+		return false
+	case keyQuit:
+		if !d.running || d.quitting {
+			d.mu.Unlock()
+			return true
+		}
+		d.quitting = true
+		d.message = "The plan is still running: press q again to stop it and quit"
+		d.mu.Unlock()
+		return false
+	}
+
+	var stepID string
+	var status orchestrator.TaskStatus
+	if d.selected < len(d.steps) {
+		stepID = d.steps[d.selected].ID
+		status = d.steps[d.selected].Status
+	}
+	running := d.running
+	d.mu.Unlock()
+	if stepID == "" {
+		return false
+	}
+
+	var err error
+	var message string
+	switch k {
+	case keyRetry:
+		err = d.manager.RetryStep(ctx, d.planID, stepID)
+		message = "🔁 Retrying " + stepID
+		if err == nil && status != orchestrator.TaskStatusInProgress {
+			if running {
+				d.mu.Lock()
+				d.retried = append(d.retried, stepID)
+				d.mu.Unlock()
+			} else {
+				d.start(ctx)
+			}
+		}
+	case keyCancel:
+		err = d.manager.CancelStep(ctx, d.planID, stepID)
+		message = "⏹️  Cancelled " + stepID
+	case keyReassign:
+		err = d.manager.ReassignStep(ctx, stepID)
+		message = "🔀 Moving " + stepID + " to another worker"
+	}
+	if err != nil {
+		message = "❌ " + err.Error()
+	}
+
+	d.mu.Lock()
+	d.message = message
+	d.mu.Unlock()
+	d.addLog(message)
+	return false
+}
+
+// captureStdout sends everything printed while the dashboard is shown to
+// the event log instead of the screen
+func (d *Dashboard) captureStdout() (func(), error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture output: %w", err)
+	}
+
+	original := os.Stdout
+	os.Stdout = writer
+	go func() {
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				d.addLog(line)
+			}
+		}
+	}()
+
+	return func() {
+		os.Stdout = original
+		writer.Close()
+	}, nil
+}
+
+func (d *Dashboard) addLog(line string) {
+	d.mu.Lock()
+	d.log = append(d.log, line)
+	if len(d.log) > maxLogLines {
+		d.log = d.log[len(d.log)-maxLogLines:]
+	}
+	d.mu.Unlock()
+
+	select {
+	case d.redraw <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Dashboard) refreshSteps(ctx context.Context) {
+	steps, err := d.manager.PlanSteps(ctx, d.planID)
+	if err != nil {
+		return
+	}
+	workers := d.manager.WorkerPool().Workers()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.steps = steps
+	d.workers = workers
+	if d.selected >= len(steps) {
+		d.selected = max(len(steps)-1, 0)
+	}
+}
+
+// refreshTails captures the latest output of every worker pane
+func (d *Dashboard) refreshTails() {
+	workers := d.manager.WorkerPool().Workers()
+	tails := make(map[string][]string, len(workers))
+	for _, worker := range workers {
+		content, err := d.manager.CapturePane(worker.ID, 50)
+		if err != nil {
+			continue
+		}
+		tails[worker.ID] = tail(content, 10)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tails = tails
+}
+
+func (d *Dashboard) step(stepID string) *orchestrator.TaskStep {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range d.steps {
+		if d.steps[i].ID == stepID {
+			step := d.steps[i]
+			return &step
+		}
+	}
+	return nil
+}
+
+// render lays out the screen: header, steps, workers, log and key help
+func (d *Dashboard) render(rows, cols int) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	header := fit(d.header(), cols)
+	lines := []string{
+		ansiReverse + ansiBold + header + strings.Repeat(" ", max(cols-width(header), 0)),
+		d.progress(cols),
+	}
+
+	// Steps and workers get a third of the screen each at most, the log the rest
+	avail := rows - len(lines) - 1
+	stepRows := min(len(d.steps), max(avail/3-1, 3))
+	lines = append(lines, section("Steps", cols))
+	lines = append(lines, d.renderSteps(stepRows, cols)...)
+
+	workerRows := max(avail/3-1, 1)
+	tailLines := d.TailLines
+	for tailLines > 0 && len(d.workers)*(1+tailLines) > workerRows {
+		tailLines--
+	}
+	lines = append(lines, section("Workers", cols))
+	lines = append(lines, d.renderWorkers(workerRows, tailLines, cols)...)
+
+	logRows := rows - len(lines) - 2
+	lines = append(lines, section("Log", cols))
+	if logRows > 0 {
+		start := max(len(d.log)-logRows, 0)
+		for _, line := range d.log[start:] {
+			lines = append(lines, " "+fit(sanitize(line), cols-1))
+		}
+	}
+
+	for len(lines) < rows-1 {
+		lines = append(lines, "")
+	}
+	if len(lines) > rows-1 {
+		lines = lines[:max(rows-1, 0)]
+	}
+
+	footer := " ↑↓/jk select  r retry  c cancel  a reassign  q quit"
+	if d.message != "" {
+		footer += "  │  " + d.message
+	}
+	footer = fit(footer, cols)
+	lines = append(lines, ansiReverse+footer+strings.Repeat(" ", max(cols-width(footer), 0)))
+	return lines
+}
+
+func (d *Dashboard) header() string {
+	state := "finished"
+	if d.running {
+		state = "running"
+	} else if d.result != nil {
+		state = "failed"
+	}
+	return fmt.Sprintf(" Claude Company │ %s │ plan %s │ %s", d.title, d.planID, state)
+}
+
+func (d *Dashboard) progress(cols int) string {
+	counts := make(map[orchestrator.TaskStatus]int)
+	for _, step := range d.steps {
+		counts[step.Status]++
+	}
+
+	barWidth := min(30, max(cols-60, 10))
+	filled := 0
+	if len(d.steps) > 0 {
+		filled = barWidth * counts[orchestrator.TaskStatusCompleted] / len(d.steps)
+	}
+	bar := ansiGreen + strings.Repeat("█", filled) + ansiGray + strings.Repeat("░", barWidth-filled) + ansiReset
+	summary := fmt.Sprintf(" %d/%d completed, %d running, %d failed, %d cancelled",
+		counts[orchestrator.TaskStatusCompleted], len(d.steps), counts[orchestrator.TaskStatusInProgress],
+		counts[orchestrator.TaskStatusFailed], counts[orchestrator.TaskStatusCancelled])
+	return " " + bar + fit(summary, max(cols-barWidth-1, 0))
+}
+
+// renderSteps draws the steps in plan order, indented by their depth in the
+// dependency graph, scrolled so that the selected step is visible
+func (d *Dashboard) renderSteps(rows, cols int) []string {
+	if len(d.steps) == 0 {
+		return []string{ansiDim + "  (no steps)"}
+	}
+
+	depths := stepDepths(d.steps)
+	orders := make(map[string]int, len(d.steps))
+	for _, step := range d.steps {
+		orders[step.ID] = step.Order
+	}
+	workers := make(map[string]string)
+	for _, worker := range d.workers {
+		if worker.CurrentTask != nil {
+			workers[*worker.CurrentTask] = worker.Name
+		}
+	}
+
+	offset := 0
+	if d.selected >= rows {
+		offset = d.selected - rows + 1
+	}
+
+	var lines []string
+	for i := offset; i < len(d.steps) && i < offset+rows; i++ {
+		step := d.steps[i]
+		symbol, color := statusStyle(step.Status)
+
+		marker := "  "
+		if i == d.selected {
+			marker = "▶ "
+		}
+		indent := strings.Repeat("  ", depths[step.ID])
+		if depths[step.ID] > 0 {
+			indent += "└ "
+		}
+
+		details := []string{string(step.Status)}
+		if worker, ok := workers[step.ID]; ok {
+			details = append(details, worker)
+		}
+		if len(step.Dependencies) > 0 {
+			after := make([]string, len(step.Dependencies))
+			for j, dep := range step.Dependencies {
+				after[j] = fmt.Sprintf("#%d", orders[dep])
+			}
+			details = append(details, "after "+strings.Join(after, ","))
+		}
+		if step.Error != nil {
+			details = append(details, step.Error.Message)
+		}
+
+		line := fmt.Sprintf("%s%s%s #%d %s  [%s]", marker, indent, symbol, step.Order, step.Name, strings.Join(details, " · "))
+		style := color
+		if i == d.selected {
+			style += ansiReverse
+		}
+		lines = append(lines, style+fit(sanitize(line), cols))
+	}
+	return lines
+}
+
+func (d *Dashboard) renderWorkers(rows, tailLines, cols int) []string {
+	if len(d.workers) == 0 {
+		return []string{ansiDim + "  (no workers yet)"}
+	}
+
+	var lines []string
+	for _, worker := range d.workers {
+		if len(lines) >= rows {
+			break
+		}
+		color := ansiGray
+		switch worker.Status {
+		case orchestrator.WorkerStatusBusy:
+			color = ansiYellow
+		case orchestrator.WorkerStatusIdle:
+			color = ansiGreen
+		case orchestrator.WorkerStatusOffline:
+			color = ansiRed
+		}
+
+		line := fmt.Sprintf("  %s (%s) %s  %s", worker.Name, worker.Type, worker.ID, worker.Status)
+		if worker.CurrentTask != nil {
+			line += "  " + *worker.CurrentTask
+		}
+		lines = append(lines, color+fit(line, cols))
+
+		paneTail := d.tails[worker.ID]
+		for _, tailLine := range paneTail[max(len(paneTail)-tailLines, 0):] {
+			lines = append(lines, ansiDim+fit("    │ "+sanitize(tailLine), cols))
+		}
+	}
+	return lines
+}
+
+func section(title string, cols int) string {
+	line := "── " + title + " "
+	return ansiBold + ansiCyan + line + strings.Repeat("─", max(cols-width(line), 0))
+}
+
+func statusStyle(status orchestrator.TaskStatus) (string, string) {
+	switch status {
+	case orchestrator.TaskStatusInProgress:
+		return "◐", ansiYellow
+	case orchestrator.TaskStatusCompleted:
+		return "●", ansiGreen
+	case orchestrator.TaskStatusFailed:
+		return "✖", ansiRed
+	case orchestrator.TaskStatusCancelled:
+		return "⊘", ansiMagenta
+	default:
+		return "○", ansiGray
+	}
+}
+
+// stepDepths returns each step's depth in the dependency graph: 0 for steps
+// without dependencies, otherwise one more than the deepest dependency
+func stepDepths(steps []orchestrator.TaskStep) map[string]int {
+	deps := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		deps[step.ID] = step.Dependencies
+	}
+
+	depths := make(map[string]int, len(steps))
+	visiting := make(map[string]bool)
+	var depth func(id string) int
+	depth = func(id string) int {
+		if d, ok := depths[id]; ok {
+			return d
+		}
+		if visiting[id] {
+			return 0
+		}
+		visiting[id] = true
+		d := 0
+		for _, dep := range deps[id] {
+			if _, exists := deps[dep]; exists {
+				d = max(d, depth(dep)+1)
+			}
+		}
+		depths[id] = d
+		return d
+	}
+
+	ids := make([]string, 0, len(steps))
+	for _, step := range steps {
+		ids = append(ids, step.ID)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		depth(id)
+	}
+	return depths
+}
+
+// formatEvent renders an orchestrator event as one log line
+func formatEvent(event orchestrator.TaskEvent) string {
+	var b strings.Builder
+	b.WriteString(event.Timestamp.Format("15:04:05"))
+	b.WriteString(" " + string(event.Type))
+
+	subject := event.TaskID
+	if stepID, ok := event.Data["step_id"]; ok {
+		subject = fmt.Sprint(stepID)
+	} else if planID, ok := event.Data["plan_id"]; ok {
+		subject = fmt.Sprint(planID)
+	}
+	if subject != "" {
+		b.WriteString(" " + subject)
+	}
+
+	if status, ok := event.Data["status"]; ok {
+		fmt.Fprintf(&b, " → %v", status)
+	}
+	if attempt, ok := event.Data["attempt"]; ok {
+		fmt.Fprintf(&b, " (attempt %v)", attempt)
+	}
+	if reason, ok := event.Data["reason"]; ok {
+		fmt.Fprintf(&b, ": %v", reason)
+	}
+	if err, ok := event.Data["error"]; ok && err != nil {
+		fmt.Fprintf(&b, ": %v", err)
+	}
+	return b.String()
+}
+
+// tail returns the last n non-empty lines of pane content
+func tail(content string, n int) []string {
+	lines := strings.Split(strings.TrimRight(content, "\n "), "\n")
+	var result []string
+	for i := len(lines) - 1; i >= 0 && len(result) < n; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			result = append([]string{strings.TrimRight(lines[i], " ")}, result...)
+		}
+	}
+	return result
+}
+
+// sanitize removes characters that would move the cursor
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t':
+			return ' '
+		case r < 0x20 || r == 0x7f:
+			return -1
+		default:
+			return r
+		}
+	}, s)
+}
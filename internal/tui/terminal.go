@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ANSI escape sequences used to draw the dashboard
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiReverse = "\x1b[7m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+	ansiGray    = "\x1b[90m"
+)
+
+// terminal switches the controlling terminal to raw mode and the alternate
+// screen, and restores it on Close
+type terminal struct {
+	out   *os.File
+	saved string // stty settings before raw mode
+}
+
+// CheckTerminal fails when stdin is not a terminal the UI can drive
+func CheckTerminal() error {
+	if _, err := stty("-g"); err != nil {
+		return fmt.Errorf("the UI needs an interactive terminal: %w", err)
+	}
+	return nil
+}
+
+func openTerminal(out *os.File) (*terminal, error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("the UI needs an interactive terminal: %w", err)
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, fmt.Errorf("failed to set raw mode: %w", err)
+	}
+
+	// Alternate screen, hidden cursor
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	return &terminal{out: out, saved: strings.TrimSpace(saved)}, nil
+}
+
+func (t *terminal) Close() {
+	fmt.Fprint(t.out, ansiReset+"\x1b[?25h\x1b[?1049l")
+	stty(t.saved)
+}
+
+// Size returns the terminal size, or 24x80 when it cannot be read
+func (t *terminal) Size() (rows, cols int) {
+	output, err := stty("size")
+	if err == nil {
+		fields := strings.Fields(output)
+		if len(fields) == 2 {
+			rows, _ = strconv.Atoi(fields[0])
+			cols, _ = strconv.Atoi(fields[1])
+		}
+	}
+	if rows <= 0 || cols <= 0 {
+		return 24, 80
+	}
+	return rows, cols
+}
+
+// Draw replaces the screen with lines
+func (t *terminal) Draw(lines []string) {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString(ansiReset + "\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+	io.WriteString(t.out, b.String())
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	output, err := cmd.Output()
+	return string(output), err
+}
+
+// key is a key binding of the dashboard
+type key int
+
+const (
+	keyUp key = iota
+	keyDown
+	keyRetry
+	keyCancel
+	keyReassign
+	keyQuit
+)
+
+// readKeys decodes key presses from in until it fails
+func readKeys(in io.Reader, keys chan<- key) {
+	buf := make([]byte, 64)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+		for i := 0; i < n; i++ {
+			switch b := buf[i]; {
+			case b == 0x1b && i+2 < n && buf[i+1] == '[':
+				switch buf[i+2] {
+				case 'A':
+					keys <- keyUp
+				case 'B':
+					keys <- keyDown
+				}
+				i += 2
+			case b == 'k':
+				keys <- keyUp
+			case b == 'j':
+				keys <- keyDown
+			case b == 'r':
+				keys <- keyRetry
+			case b == 'c':
+				keys <- keyCancel
+			case b == 'a':
+				keys <- keyReassign
+			case b == 'q', b == 0x03: // q or Ctrl-C
+				keys <- keyQuit
+			}
+		}
+	}
+}
+
+// width returns the number of terminal columns s occupies
+func width(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// runeWidth treats East Asian wide characters and emoji as two columns
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x1100:
+		return 1
+	case r <= 0x115f,
+		r >= 0x2e80 && r <= 0xa4cf,
+		r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff,
+		r >= 0xfe30 && r <= 0xfe4f,
+		r >= 0xff00 && r <= 0xff60,
+		r >= 0xffe0 && r <= 0xffe6,
+		r >= 0x1f300 && r <= 0x1faff:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// fit cuts s to at most cols columns
+func fit(s string, cols int) string {
+	if width(s) <= cols {
+		return s
+	}
+	var b strings.Builder
+	w := 0
+	for _, r := range s {
+		rw := runeWidth(r)
+		if w+rw > cols-1 {
+			break
+		}
+		b.WriteRune(r)
+		w += rw
+	}
+	b.WriteString("…")
+	return b.String()
+}
@@ -1,24 +1,40 @@
 package main
 
 import (
-	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 	"claude-company/internal/commands"
+	"claude-company/internal/config"
 	"claude-company/internal/session"
 )
 
 func main() {
+	// Subcommands; the legacy flag-only form is handled below
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		os.Exit(runSubcommand(os.Args[1], os.Args[2:]))
+	}
+
 	var setup bool
 	var taskDesc string
 	var orchestrate bool
 	var help bool
-	
+	var storage string
+	var readyTimeout time.Duration
+	var configPath string
+	var sessionName string
+
 	flag.BoolVar(&setup, "setup", false, "Setup Claude Company tmux session")
 	flag.StringVar(&taskDesc, "task", "", "Task description")
 	flag.BoolVar(&orchestrate, "orchestrate", false, "Enable orchestrator mode for step-based task management")
+	flag.StringVar(&storage, "storage", session.StorageBackendFile, "Storage backend for orchestrator state (file or sqlite)")
+	flag.DurationVar(&readyTimeout, "ready-timeout", 60*time.Second, "How long to wait for Claude to start in a pane")
+	flag.StringVar(&configPath, "config", "", "Configuration file (default: $CLAUDE_COMPANY_CONFIG or .claude-company.yaml when present)")
+	flag.StringVar(&sessionName, "session", "", "tmux session name (default: session.name, $CLAUDE_COMPANY_SESSION or claude-<project directory>)")
 	flag.BoolVar(&help, "help", false, "Show help information")
 	flag.Parse()
 
@@ -28,7 +44,19 @@ func main() {
 		return
 	}
 
-	manager := session.NewManager("claude-squad", "claude --dangerously-skip-permissions")
+	cfg, err := config.Resolve(configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if sessionName != "" {
+		cfg.Session.Name = sessionName
+	}
+	manager := session.NewManagerFromConfig(cfg)
+
+	if err := manager.SetStorageBackend(storage); err != nil {
+		log.Fatal(err)
+	}
+	manager.SetReadinessTimeout(readyTimeout)
 
 	// Set orchestrator mode if requested
 	if orchestrate {
@@ -45,39 +73,177 @@ func main() {
 	}
 
 	if taskDesc != "" {
-		ctx := context.Background()
+		ctx, stop := commands.SignalContext()
 		deploy := commands.NewDeployCommand(taskDesc, manager)
-		if err := deploy.Execute(ctx); err != nil {
-			log.Fatal(err)
+		err := deploy.Execute(ctx)
+		stop()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(commands.ExitCode(err))
 		}
 	}
 }
 
+// runSubcommand runs a subcommand and returns the process exit code
+func runSubcommand(name string, args []string) int {
+	var cmd commands.Command
+	switch name {
+	case "setup":
+		cmd = commands.NewSetupCommand(args)
+	case "task":
+		cmd = commands.NewTaskCommand(args)
+	case "run":
+		cmd = commands.NewRunCommand(args)
+	case "status":
+		cmd = commands.NewStatusCommand(args)
+	case "ui":
+		cmd = commands.NewUICommand(args)
+	case "list":
+		cmd = commands.NewListCommand(args)
+	case "attach":
+		cmd = commands.NewAttachCommand(args)
+	case "kill":
+		cmd = commands.NewKillCommand(args)
+	case "rename":
+		cmd = commands.NewRenameCommand(args)
+	case "resume":
+		cmd = commands.NewResumeCommand(args)
+	case "logs":
+		cmd = commands.NewLogsCommand(args)
+	case "report":
+		cmd = commands.NewReportCommand(args)
+	case "help":
+		showHelp()
+		return commands.ExitOK
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", name)
+		showHelp()
+		return commands.ExitUsage
+	}
+
+	ctx, stop := commands.SignalContext()
+	defer stop()
+
+	err := cmd.Execute(ctx)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+	}
+	return commands.ExitCode(err)
+}
+
 func showHelp() {
 	fmt.Println("Claude Company - AI Task Management System")
 	fmt.Println()
 	fmt.Println("USAGE:")
+	fmt.Println("  claude-company <command> [flags] [args]")
 	fmt.Println("  claude-company [OPTIONS]")
 	fmt.Println()
-	fmt.Println("OPTIONS:")
+	fmt.Println("COMMANDS:")
+	fmt.Println("  setup                Create the tmux session (or attach to it if it exists)")
+	fmt.Println("  task <description>   Assign a task to the AI team (or --file spec.md / --stdin)")
+	fmt.Println("                       --dry-run prints the plan and step prompts without touching tmux")
+	fmt.Println("  task add <desc>      Queue a task (--priority high|medium|low, --type)")
+	fmt.Println("  task list            List the running and queued tasks")
+	fmt.Println("  task cancel <id>     Remove a task from the queue")
+	fmt.Println("  task run             Execute queued tasks by priority (--watch keeps waiting for new ones)")
+	fmt.Println("  run <manifest.yaml>  Create the tasks of a manifest and execute them in dependency order")
+	fmt.Println("  status               Show the panes of the session and the latest tasks")
+	fmt.Println("  ui <description>     Run a task in orchestrator mode under a live dashboard")
+	fmt.Println("  list                 List the companies running on this machine (--all: every tmux session)")
+	fmt.Println("  attach [session]     Attach to a session")
+	fmt.Println("  kill [session]       Kill a session and its worker panes")
+	fmt.Println("  rename <old> <new>   Rename a session")
+	fmt.Println("  resume [plan-id]     Continue a plan whose process died (the latest one by default);")
+	fmt.Println("                       otherwise return to the session, recreating it if needed")
+	fmt.Println("  logs                 Show the orchestrator event log (--follow, --task, --type) or a pane (--pane)")
+	fmt.Println("  report               Report a finished step from a worker pane")
+	fmt.Println()
+	fmt.Println("  Run `claude-company <command> -h` for the flags of a command.")
+	fmt.Println()
+	fmt.Println("OPTIONS (without a command):")
 	fmt.Println("  --setup              Setup Claude Company tmux session (default behavior)")
 	fmt.Println("  --task <description> Assign a task to AI team")
 	fmt.Println("  --orchestrate        Enable orchestrator mode for step-based task management")
+	fmt.Println("  --storage <backend>  Storage backend for orchestrator state: file (default) or sqlite")
+	fmt.Println("  --ready-timeout <d>  How long to wait for Claude to start in a pane (default 60s)")
+	fmt.Println("  --config <file>      Configuration file (also accepted by the commands)")
+	fmt.Println("  --session <name>     tmux session name (also accepted by the commands)")
 	fmt.Println("  --help               Show this help information")
 	fmt.Println()
+	fmt.Println("CONFIGURATION:")
+	fmt.Println("  Settings are read from --config, $CLAUDE_COMPANY_CONFIG, or the first of")
+	fmt.Println("  .claude-company.yaml, .claude/orchestrator.yaml and orchestrator.yaml found in the")
+	fmt.Println("  current directory, e.g.")
+	fmt.Println("    session: {name: my-repo, layout: tiled}")
+	fmt.Println("    workers: {max_workers: 6, roles: [developer, tester, reviewer], task_timeout: 1800}")
+	fmt.Println("    defaults: {claude_command: claude, claude_flags: [--dangerously-skip-permissions]}")
+	fmt.Println("  session.layout arranges the workers: tiled (default), main-vertical (parent pane on the")
+	fmt.Println("  left, workers on the right) or windows (one window per worker). Panes and windows are")
+	fmt.Println("  titled with session.pane_prefix / session.window_prefix, the worker name and its role.")
+	fmt.Println("  Without session.name the session is named claude-<directory> after the current")
+	fmt.Println("  directory, so each project runs its own company; `claude-company list` shows them")
+	fmt.Println("  (registered under the user config directory, or $CLAUDE_COMPANY_REGISTRY).")
+	fmt.Println("  Environment variables override the file: CLAUDE_COMPANY_SESSION, CLAUDE_COMPANY_LAYOUT,")
+	fmt.Println("  CLAUDE_COMPANY_MAX_WORKERS, CLAUDE_COMPANY_WORKER_ROLES (comma-separated),")
+	fmt.Println("  CLAUDE_COMPANY_TASK_TIMEOUT (seconds), CLAUDE_COMPANY_CLAUDE_COMMAND,")
+	fmt.Println("  CLAUDE_COMPANY_CLAUDE_FLAGS and CLAUDE_COMPANY_LANGUAGE.")
+	fmt.Println()
+	fmt.Println("EXIT CODES:")
+	fmt.Println("  0  success")
+	fmt.Println("  1  the command failed")
+	fmt.Println("  2  invalid flags or arguments")
+	fmt.Println("  3  the tmux session does not exist")
+	fmt.Println("  4  task, plan or pane not found")
+	fmt.Println("  5  tmux is not installed")
+	fmt.Println("  130  stopped by Ctrl-C or SIGTERM; the plan state is saved for resume")
+	fmt.Println("       (busy workers keep working unless --interrupt-workers is given)")
+	fmt.Println()
 	fmt.Println("EXAMPLES:")
-	fmt.Println("  claude-company")
+	fmt.Println("  claude-company setup")
 	fmt.Println("    Set up tmux session with traditional manager mode")
 	fmt.Println()
-	fmt.Println("  claude-company --orchestrate")
-	fmt.Println("    Set up tmux session with orchestrator mode enabled")
-	fmt.Println()
-	fmt.Println("  claude-company --task \"Implement user authentication\"")
+	fmt.Println("  claude-company task \"Implement user authentication\"")
 	fmt.Println("    Assign task using traditional delegation mode")
 	fmt.Println()
-	fmt.Println("  claude-company --orchestrate --task \"Implement user authentication\"")
+	fmt.Println("  claude-company task --orchestrate \"Implement user authentication\"")
 	fmt.Println("    Assign task using orchestrator mode with step-based execution")
 	fmt.Println()
+	fmt.Println("  claude-company task --dry-run --file spec.md")
+	fmt.Println("    Show the steps, dependency batches, estimated time and worker prompts")
+	fmt.Println("    the task would run with, without a session")
+	fmt.Println()
+	fmt.Println("  claude-company task --orchestrate --file spec.md")
+	fmt.Println("    Assign a task written in markdown; YAML front-matter sets type, priority,")
+	fmt.Println("    project_path, branch and metadata, e.g.")
+	fmt.Println("      ---")
+	fmt.Println("      type: bugfix")
+	fmt.Println("      priority: high")
+	fmt.Println("      ---")
+	fmt.Println("      # Login redirects to a 404")
+	fmt.Println()
+	fmt.Println("  claude-company task add --priority high \"Fix the login redirect\"")
+	fmt.Println("  claude-company task run --watch")
+	fmt.Println("    Queue tasks and let the orchestrator work through them, highest priority first")
+	fmt.Println()
+	fmt.Println("  claude-company run tasks.yaml")
+	fmt.Println("    Run several tasks; each entry has an id, a title/description or a spec file,")
+	fmt.Println("    and optional depends_on, e.g.")
+	fmt.Println("      tasks:")
+	fmt.Println("        - id: api")
+	fmt.Println("          title: Add the login API")
+	fmt.Println("        - id: ui")
+	fmt.Println("          file: specs/login-form.md")
+	fmt.Println("          depends_on: [api]")
+	fmt.Println()
+	fmt.Println("  claude-company ui \"Implement user authentication\"")
+	fmt.Println("    Follow the plan live; retry (r), cancel (c) or reassign (a) the selected step")
+	fmt.Println()
+	fmt.Println("  claude-company logs --follow --type task_failed,task_retried")
+	fmt.Println("    Watch failing and retried steps")
+	fmt.Println()
+	fmt.Println("  claude-company report --step task_1_step_2 --status completed --summary \"Added login API\"")
+	fmt.Println("    Report a finished step from a worker pane to the orchestrator")
+	fmt.Println()
 	fmt.Println("MODES:")
 	fmt.Println("  Traditional Manager Mode:")
 	fmt.Println("    - Basic task delegation to child panes")
@@ -90,4 +256,4 @@ func showHelp() {
 	fmt.Println("    - Parallel execution optimization")
 	fmt.Println("    - Quality monitoring and automatic retries")
 	fmt.Println("    - Learning-based improvement")
-}
\ No newline at end of file
+}